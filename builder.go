@@ -0,0 +1,91 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+// NewConfigBuilder returns a new ConfigBuilder
+func NewConfigBuilder() *ConfigBuilder {
+	return &ConfigBuilder{
+		config: Config{
+			Writers: make(map[string]WriterConfig),
+			Loggers: make(map[string]LoggerConfig),
+		},
+	}
+}
+
+// ConfigBuilder builds a logging Config
+type ConfigBuilder struct {
+	config Config
+}
+
+// WithConsoleEncoder sets the fields written by the console encoder
+func (b *ConfigBuilder) WithConsoleEncoder(fields EncoderFieldsConfig) *ConfigBuilder {
+	b.config.Encoders.Console.Fields = fields
+	return b
+}
+
+// WithJSONEncoder sets the fields written by the JSON encoder
+func (b *ConfigBuilder) WithJSONEncoder(fields EncoderFieldsConfig) *ConfigBuilder {
+	b.config.Encoders.JSON.Fields = fields
+	return b
+}
+
+// WithStdoutWriter adds a writer that writes to stdout with the given encoding
+func (b *ConfigBuilder) WithStdoutWriter(encoding Encoding) *ConfigBuilder {
+	b.config.Writers["stdout"] = WriterConfig{Encoder: encoding}
+	return b
+}
+
+// WithStderrWriter adds a writer that writes to stderr with the given encoding
+func (b *ConfigBuilder) WithStderrWriter(encoding Encoding) *ConfigBuilder {
+	b.config.Writers["stderr"] = WriterConfig{Encoder: encoding}
+	return b
+}
+
+// WithFileWriter adds a named writer that writes to the file at the given path with the given encoding
+func (b *ConfigBuilder) WithFileWriter(name string, path string, encoding Encoding) *ConfigBuilder {
+	b.config.Writers[name] = WriterConfig{
+		Encoder: encoding,
+		Path:    path,
+	}
+	return b
+}
+
+// WithRootLogger sets the configuration of the root logger
+func (b *ConfigBuilder) WithRootLogger(config LoggerConfig) *ConfigBuilder {
+	b.config.RootLogger = config
+	return b
+}
+
+// WithLogger sets the configuration of the logger with the given path
+func (b *ConfigBuilder) WithLogger(path string, config LoggerConfig) *ConfigBuilder {
+	b.config.Loggers[path] = config
+	return b
+}
+
+// Build returns the logging Config
+func (b *ConfigBuilder) Build() Config {
+	config := b.config
+	config.Writers = make(map[string]WriterConfig)
+	for name, writer := range b.config.Writers {
+		config.Writers[name] = writer
+	}
+	config.Loggers = make(map[string]LoggerConfig)
+	for path, logger := range b.config.Loggers {
+		config.Loggers[path] = logger
+	}
+	return config
+}
+
+// NewLoggerConfig returns a LoggerConfig with the given level that writes to the given writers
+func NewLoggerConfig(level Level, writers ...string) LoggerConfig {
+	config := LoggerConfig{
+		Level:   level,
+		Outputs: make(map[string]OutputConfig),
+	}
+	for _, writer := range writers {
+		config.Outputs[writer] = OutputConfig{}
+	}
+	return config
+}
@@ -0,0 +1,111 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+func TestConfigBuilder(t *testing.T) {
+	config := NewConfigBuilder().
+		WithJSONEncoder(EncoderFieldsConfig{
+			Message: &FieldConfig{Key: "msg"},
+			Level:   &LevelFieldConfig{Format: UpperCaseLevelFormat},
+		}).
+		WithStdoutWriter(JSONEncoding).
+		WithFileWriter("file", "test.log", ConsoleEncoding).
+		WithRootLogger(NewLoggerConfig(InfoLevel, "stdout")).
+		WithLogger("foo/bar", LoggerConfig{
+			Level: DebugLevel,
+			Sample: &SamplerConfig{
+				Basic: &BasicSamplerConfig{Interval: 10, MaxLevel: InfoLevel},
+			},
+		}).
+		Build()
+
+	loggingConfig, err := config.loggingConfig()
+	assert.NoError(t, err)
+	assert.Equal(t, "msg", loggingConfig.Encoders.JSON.Fields.Message.Key)
+	assert.Equal(t, UpperCaseLevelFormat, *loggingConfig.Encoders.JSON.Fields.Level.Format)
+	assert.Nil(t, loggingConfig.Encoders.JSON.Fields.Time)
+	assert.Equal(t, JSONEncoding, loggingConfig.Writers.Stdout.Encoder)
+	assert.Nil(t, loggingConfig.Writers.Stderr)
+	assert.Equal(t, "test.log", loggingConfig.Writers.Files["file"].Path)
+	assert.Equal(t, InfoLevel, loggingConfig.RootLogger.Level.Level())
+	assert.Contains(t, loggingConfig.RootLogger.Outputs.Outputs, "stdout")
+	assert.Equal(t, DebugLevel, loggingConfig.Loggers["foo/bar"].Level.Level())
+	assert.Equal(t, 10, loggingConfig.Loggers["foo/bar"].Sample.Basic.Interval)
+
+	config.Writers["stderr"] = WriterConfig{}
+	_, err = config.loggingConfig()
+	assert.Error(t, err)
+	delete(config.Writers, "stderr")
+
+	config.Writers["file"] = WriterConfig{Encoder: JSONEncoding}
+	_, err = config.loggingConfig()
+	assert.Error(t, err)
+	delete(config.Writers, "file")
+
+	config.RootLogger.Sample = &SamplerConfig{
+		Basic:  &BasicSamplerConfig{},
+		Random: &RandomSamplerConfig{},
+	}
+	_, err = config.loggingConfig()
+	assert.Error(t, err)
+}
+
+func TestConfigure(t *testing.T) {
+	defer resetRootLogger()()
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&defaultFramework{}, loggingConfig{}, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+	log := GetLogger("configure")
+
+	config := NewConfigBuilder().
+		WithJSONEncoder(EncoderFieldsConfig{
+			Message: &FieldConfig{},
+		}).
+		WithStdoutWriter(JSONEncoding).
+		WithRootLogger(NewLoggerConfig(InfoLevel, "stdout")).
+		Build()
+	assert.NoError(t, Configure(&defaultFramework{}, config))
+	assert.Equal(t, InfoLevel, log.Level())
+
+	config.RootLogger = NewLoggerConfig(InfoLevel, "stderr")
+	assert.Error(t, Configure(&defaultFramework{}, config))
+	assert.Equal(t, InfoLevel, log.Level())
+
+	// Outputs of loggers that don't exist yet are checked before the configuration is applied
+	config.RootLogger = NewLoggerConfig(InfoLevel, "stdout")
+	config.Loggers = map[string]LoggerConfig{
+		"a": NewLoggerConfig(DebugLevel, "missing"),
+	}
+	assert.EqualError(t, Configure(&defaultFramework{}, config), "logger 'a' output references undefined writer 'missing'")
+	assert.NotPanics(t, func() {
+		GetLogger("a/b").Info("Hello world!")
+	})
+	assert.Equal(t, InfoLevel, GetLogger("a/b").Level())
+
+	config.Loggers = nil
+	config.RootLogger.Sample = &SamplerConfig{
+		Basic: &BasicSamplerConfig{},
+	}
+	assert.EqualError(t, Configure(&defaultFramework{}, config), "root logger: sampling interval must be a positive integer")
+	config.RootLogger.Sample = &SamplerConfig{
+		Random: &RandomSamplerConfig{Interval: -1},
+	}
+	assert.EqualError(t, Configure(&defaultFramework{}, config), "root logger: sampling interval must be a positive integer")
+	assert.NotPanics(t, func() {
+		log.Info("Hello world!")
+	})
+
+	config.RootLogger.Sample = nil
+	assert.EqualError(t, Configure(nil, config), "no logging framework provided")
+}
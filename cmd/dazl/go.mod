@@ -0,0 +1,38 @@
+module github.com/atomix/dazl/cmd/dazl
+
+go 1.21
+
+require (
+	github.com/atomix/dazl v1.1.2
+	github.com/atomix/dazl/logrus v0.0.0-00010101000000-000000000000
+	github.com/atomix/dazl/slog v0.0.0-00010101000000-000000000000
+	github.com/atomix/dazl/zap v0.0.0-00010101000000-000000000000
+	github.com/atomix/dazl/zerolog v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.8.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/mitchellh/go-homedir v1.1.0 // indirect
+	github.com/pelletier/go-toml/v2 v2.0.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rs/zerolog v1.29.0 // indirect
+	github.com/sirupsen/logrus v1.9.3 // indirect
+	go.uber.org/atomic v1.7.0 // indirect
+	go.uber.org/multierr v1.6.0 // indirect
+	go.uber.org/zap v1.24.0 // indirect
+	golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/atomix/dazl => ../../
+
+replace github.com/atomix/dazl/logrus => ../../logrus
+
+replace github.com/atomix/dazl/slog => ../../slog
+
+replace github.com/atomix/dazl/zap => ../../zap
+
+replace github.com/atomix/dazl/zerolog => ../../zerolog
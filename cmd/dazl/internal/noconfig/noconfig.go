@@ -0,0 +1,20 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package noconfig disables loading the logging configuration files when the frameworks are registered.
+// The frameworks validate the configuration in the working directory when they're initialized, so a
+// configuration that's invalid for any framework would otherwise crash the tool used to diagnose it.
+//
+// Since Go 1.21, packages are initialized in the order of their import paths, each package once the packages it
+// imports are initialized. This package imports only dazl, which every framework package imports too, and its
+// path sorts before the paths of the framework packages, so it's initialized before any framework is registered.
+// The go directive in this module's go.mod requires Go 1.21 to keep that order, and the command checks
+// dazl.AutoConfigured before it uses any framework in case the order changes.
+package noconfig
+
+import "github.com/atomix/dazl"
+
+func init() {
+	dazl.DisableAutoConfigure()
+}
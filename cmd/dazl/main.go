@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Command dazl validates and inspects dazl logging configuration files.
+package main
+
+import (
+	// noconfig disables loading the configuration files before the frameworks are registered
+	_ "github.com/atomix/dazl/cmd/dazl/internal/noconfig"
+
+	"errors"
+	"flag"
+	"fmt"
+	"github.com/atomix/dazl"
+	"github.com/atomix/dazl/logrus"
+	"github.com/atomix/dazl/slog"
+	"github.com/atomix/dazl/zap"
+	"github.com/atomix/dazl/zerolog"
+	"io"
+	"os"
+	"sort"
+	"strings"
+)
+
+const usage = `Usage:
+  dazl validate [--framework <name>] <file>...
+  dazl explain [--config <file>]... <logger>
+`
+
+// frameworks are the frameworks a configuration can be validated against, keyed by name
+var frameworks = map[string]dazl.Framework{
+	"logrus":  &logrus.Framework{},
+	"slog":    &slog.Framework{},
+	"zap":     &zap.Framework{},
+	"zerolog": &zerolog.Framework{},
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdout, stderr io.Writer) int {
+	// The configuration in the working directory must not be applied to the frameworks the tool validates
+	// against, so fail if noconfig was not initialized before the framework packages were.
+	if dazl.AutoConfigured() {
+		fmt.Fprintln(stderr, "the logging configuration was loaded before automatic configuration was disabled")
+		return 1
+	}
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+		return 2
+	}
+	switch args[0] {
+	case "validate":
+		return validate(args[1:], stdout, stderr)
+	case "explain":
+		return explain(args[1:], stdout, stderr)
+	case "help", "-h", "--help":
+		fmt.Fprint(stdout, usage)
+		return 0
+	default:
+		fmt.Fprintf(stderr, "unknown command '%s'\n%s", args[0], usage)
+		return 2
+	}
+}
+
+func validate(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("validate", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	frameworkName := flags.String("framework", "", fmt.Sprintf("the framework to validate against (%s)", strings.Join(frameworkNames(), ", ")))
+	paths, err := parse(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(paths) == 0 {
+		fmt.Fprint(stderr, "no configuration files given\n", usage)
+		return 2
+	}
+
+	var framework dazl.Framework
+	if *frameworkName != "" {
+		var ok bool
+		if framework, ok = frameworks[*frameworkName]; !ok {
+			fmt.Fprintf(stderr, "unknown framework '%s'; supported frameworks are %s\n", *frameworkName, strings.Join(frameworkNames(), ", "))
+			return 2
+		}
+	}
+
+	if err := dazl.ValidateFiles(framework, paths...); err != nil {
+		var configErrors dazl.ConfigErrors
+		if errors.As(err, &configErrors) {
+			for _, configError := range configErrors {
+				fmt.Fprintln(stderr, configError)
+			}
+		} else {
+			fmt.Fprintln(stderr, err)
+		}
+		return 1
+	}
+	fmt.Fprintf(stdout, "%s: OK\n", strings.Join(paths, ", "))
+	return 0
+}
+
+func explain(args []string, stdout, stderr io.Writer) int {
+	flags := flag.NewFlagSet("explain", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	var paths pathsFlag
+	flags.Var(&paths, "config", "a configuration file to load, in order of increasing precedence (default: the standard locations)")
+	names, err := parse(flags, args)
+	if err != nil {
+		return 2
+	}
+	if len(names) > 1 {
+		fmt.Fprint(stderr, "too many arguments\n", usage)
+		return 2
+	}
+
+	var name string
+	if len(names) == 1 {
+		name = names[0]
+	}
+	explanation, err := dazl.Explain(name, paths...)
+	if err != nil {
+		fmt.Fprintln(stderr, err)
+		return 1
+	}
+	printExplanation(stdout, explanation)
+	return 0
+}
+
+func printExplanation(out io.Writer, explanation *dazl.LoggerExplanation) {
+	name := explanation.Name
+	if name == "" {
+		name = "(root)"
+	}
+	fmt.Fprintf(out, "logger: %s\n", name)
+	if explanation.Level == dazl.EmptyLevel {
+		fmt.Fprintln(out, "level:  (not set, debug and above are enabled)")
+	} else {
+		fmt.Fprintf(out, "level:  %s (from %s)\n", explanation.Level, explanation.LevelSource)
+	}
+	if explanation.Sample == nil {
+		fmt.Fprintln(out, "sample: (not set, all entries are written)")
+	} else {
+		fmt.Fprintf(out, "sample: %s (from %s)\n", formatSampler(explanation.Sample), explanation.SampleSource)
+	}
+	if len(explanation.Outputs) == 0 {
+		fmt.Fprintln(out, "outputs: (none, entries are discarded)")
+		return
+	}
+	fmt.Fprintln(out, "outputs:")
+	for _, output := range explanation.Outputs {
+		writer := string(output.Encoder)
+		if output.Path != "" {
+			writer = fmt.Sprintf("%s, %s", writer, output.Path)
+		}
+		fmt.Fprintf(out, "  %s [%s] (from %s)\n", output.Writer, writer, output.Source)
+		if output.Level != dazl.EmptyLevel {
+			fmt.Fprintf(out, "    level:  %s (from %s)\n", output.Level, output.LevelSource)
+		}
+		if output.Sample != nil {
+			fmt.Fprintf(out, "    sample: %s (from %s)\n", formatSampler(output.Sample), output.SampleSource)
+		}
+	}
+}
+
+func formatSampler(config *dazl.SamplerConfig) string {
+	var kind string
+	var interval int
+	var maxLevel dazl.Level
+	if config.Basic != nil {
+		kind, interval, maxLevel = "basic", config.Basic.Interval, config.Basic.MaxLevel
+	} else {
+		kind, interval, maxLevel = "random", config.Random.Interval, config.Random.MaxLevel
+	}
+	if maxLevel == dazl.EmptyLevel {
+		return fmt.Sprintf("%s, interval %d", kind, interval)
+	}
+	return fmt.Sprintf("%s, interval %d, max level %s", kind, interval, maxLevel)
+}
+
+// parse parses the given arguments, allowing flags to follow positional arguments
+func parse(flags *flag.FlagSet, args []string) ([]string, error) {
+	var positional []string
+	for {
+		if err := flags.Parse(args); err != nil {
+			return nil, err
+		}
+		args = flags.Args()
+		if len(args) == 0 {
+			return positional, nil
+		}
+		positional = append(positional, args[0])
+		args = args[1:]
+	}
+}
+
+func frameworkNames() []string {
+	names := make([]string, 0, len(frameworks))
+	for name := range frameworks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// pathsFlag is a flag that may be set multiple times
+type pathsFlag []string
+
+func (f *pathsFlag) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *pathsFlag) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
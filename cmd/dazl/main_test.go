@@ -0,0 +1,164 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"errors"
+	"github.com/atomix/dazl"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// mainEnv is the environment variable that makes the test binary run the command instead of the tests
+const mainEnv = "DAZL_TEST_MAIN"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(mainEnv) != "" {
+		main()
+	}
+	os.Exit(m.Run())
+}
+
+const testConfig = `
+encoders:
+  console:
+    fields:
+      - message
+      - caller:
+          format: full
+writers:
+  stdout:
+    encoder: console
+  file:
+    encoder: json
+    path: app.log
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+loggers:
+  foo:
+    level: debug
+    sample:
+      basic:
+        interval: 5
+        maxLevel: info
+  foo/bar:
+    outputs:
+      file:
+        level: warn
+`
+
+const testInvalidConfig = `
+writers:
+  stdout:
+    encoder: xml
+rootLogger:
+  level: loud
+`
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(testConfig), 0644))
+	invalid := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalid, []byte(testInvalidConfig), 0644))
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 0, run([]string{"validate", path}, &stdout, &stderr))
+	assert.Equal(t, path+": OK\n", stdout.String())
+	assert.Empty(t, stderr.String())
+
+	stdout.Reset()
+	assert.Equal(t, 0, run([]string{"validate", path, "--framework", "zap"}, &stdout, &stderr))
+	assert.Equal(t, path+": OK\n", stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 1, run([]string{"validate", "--framework", "zerolog", path}, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, path+":6:9: zerolog framework: the console encoder does not support configuring caller formats\n", stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, 1, run([]string{"validate", invalid}, &stdout, &stderr))
+	assert.Equal(t, invalid+":4:14: unknown encoding 'xml'\n"+invalid+":6:10: unknown level 'loud'\n", stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, 2, run([]string{"validate", "--framework", "log4j", path}, &stdout, &stderr))
+	assert.Equal(t, "unknown framework 'log4j'; supported frameworks are logrus, slog, zap, zerolog\n", stderr.String())
+
+	stderr.Reset()
+	assert.Equal(t, 2, run([]string{"validate"}, &stdout, &stderr))
+	assert.Equal(t, 2, run([]string{"verify", path}, &stdout, &stderr))
+}
+
+func TestValidateWorkingDirectory(t *testing.T) {
+	// The frameworks are registered when the command starts, so the command is run in a new process
+	// to check that the invalid configuration in the working directory is not loaded by the frameworks
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "logging.yaml"), []byte(testInvalidConfig), 0644))
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(os.Args[0], "validate", "logging.yaml")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), mainEnv+"=true")
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	var exitErr *exec.ExitError
+	assert.True(t, errors.As(err, &exitErr))
+	assert.Equal(t, 1, exitErr.ExitCode())
+	assert.Empty(t, stdout.String())
+	assert.Equal(t, "logging.yaml:4:14: unknown encoding 'xml'\nlogging.yaml:6:10: unknown level 'loud'\n", stderr.String())
+}
+
+func TestValidatePresets(t *testing.T) {
+	dir := t.TempDir()
+	for _, preset := range dazl.Presets() {
+		path := filepath.Join(dir, preset+".yaml")
+		assert.NoError(t, os.WriteFile(path, []byte("profile: "+preset+"\n"), 0644))
+		for _, framework := range frameworkNames() {
+			var stdout, stderr bytes.Buffer
+			assert.Equal(t, 0, run([]string{"validate", "--framework", framework, path}, &stdout, &stderr), framework)
+			assert.Empty(t, stderr.String(), framework)
+		}
+	}
+}
+
+func TestExplain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logging.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(testConfig), 0644))
+
+	var stdout, stderr bytes.Buffer
+	assert.Equal(t, 0, run([]string{"explain", "--config", path, "foo/bar/baz"}, &stdout, &stderr))
+	assert.Equal(t, `logger: foo/bar/baz
+level:  debug (from loggers/foo)
+sample: basic, interval 5, max level info (from loggers/foo)
+outputs:
+  file [json, app.log] (from loggers/foo/bar)
+    level:  warn (from loggers/foo/bar)
+  stdout [console] (from rootLogger)
+`, stdout.String())
+	assert.Empty(t, stderr.String())
+
+	stdout.Reset()
+	assert.Equal(t, 0, run([]string{"explain", "--config", path}, &stdout, &stderr))
+	assert.Equal(t, `logger: (root)
+level:  info (from rootLogger)
+sample: (not set, all entries are written)
+outputs:
+  stdout [console] (from rootLogger)
+`, stdout.String())
+
+	stdout.Reset()
+	assert.Equal(t, 1, run([]string{"explain", "--config", filepath.Join(t.TempDir(), "missing.yaml"), "foo"}, &stdout, &stderr))
+	assert.Empty(t, stdout.String())
+	assert.NotEmpty(t, stderr.String())
+
+	assert.Equal(t, 2, run([]string{"explain", "--config", path, "foo", "bar"}, &stdout, &stderr))
+}
@@ -5,20 +5,27 @@
 package dazl
 
 import (
-	"github.com/mitchellh/go-homedir"
-	"gopkg.in/yaml.v3"
-	"os"
-	"path/filepath"
+	"errors"
+	"fmt"
 )
 
-const configFile = "logging.yaml"
-const configEnv = "LOGGING_CONFIG"
-
 type loggingConfig struct {
 	Encoders   encodersConfig          `json:"encoders" yaml:"encoders"`
 	Writers    writersConfig           `json:"writers" yaml:"writers"`
 	RootLogger loggerConfig            `json:"rootLogger" yaml:"rootLogger"`
 	Loggers    map[string]loggerConfig `json:"loggers" yaml:"loggers"`
+	// levels are the logger levels set by environment variables, keyed by logger environment name
+	levels map[string]Level
+	// patterns are the patterns in the loggers configuration in order of decreasing precedence
+	patterns []*loggerPattern
+}
+
+func (c *loggingConfig) getRootLogger() loggerConfig {
+	config := c.RootLogger
+	if level, ok := c.levels[""]; ok {
+		config.Level = levelConfig(level)
+	}
+	return config
 }
 
 func (c *loggingConfig) getLoggers() map[string]loggerConfig {
@@ -28,44 +35,302 @@ func (c *loggingConfig) getLoggers() map[string]loggerConfig {
 	return c.Loggers
 }
 
-func (c *loggingConfig) getLogger(name string) (loggerConfig, bool) {
+// getLogger returns the configuration of the named logger. A logger is configured by the entry for its path
+// if any, otherwise by the most specific pattern matching its path. A pattern is applied to the topmost loggers
+// it matches and inherited by their descendants, so patterns in the given list of patterns already applied to
+// the logger's ancestors are not applied again. The key of the pattern applied to the logger is returned, if any.
+func (c *loggingConfig) getLogger(name string, applied []string) (loggerConfig, string, bool) {
+	var pattern string
 	config, ok := c.getLoggers()[name]
-	return config, ok
-}
-
-// load the dazl configuration
-func load(config *loggingConfig) error {
-	configPath := os.Getenv(configEnv)
-	if configPath != "" {
-		bytes, err := os.ReadFile(configPath)
-		if err == nil {
-			return yaml.Unmarshal(bytes, config)
-		} else if !os.IsNotExist(err) {
-			return err
+	if !ok {
+		for _, loggerPattern := range c.patterns {
+			if loggerPattern.match(name) {
+				if !contains(applied, loggerPattern.key) {
+					pattern = loggerPattern.key
+					config, ok = c.Loggers[pattern], true
+				}
+				break
+			}
 		}
 	}
+	if level, found := c.levels[envName(name)]; found && name != "" {
+		config.Level = levelConfig(level)
+		ok = true
+	}
+	return config, pattern, ok
+}
 
-	bytes, err := os.ReadFile(configFile)
-	if err == nil {
-		return yaml.Unmarshal(bytes, config)
-	} else if !os.IsNotExist(err) {
+// Configure configures logging using the given framework and configuration. The configuration is applied
+// to all existing loggers, and an error is returned if the configuration is invalid or is not supported
+// by the framework.
+func Configure(framework Framework, config Config) error {
+	if framework == nil {
+		return errors.New("no logging framework provided")
+	}
+	loggingConfig, err := config.loggingConfig()
+	if err != nil {
 		return err
 	}
+	context, err := newLoggingContext(framework, loggingConfig, open)
+	if err != nil {
+		return err
+	}
+	return root.(*dazlLogger).reconfigure(context)
+}
+
+// Config is the logging configuration
+type Config struct {
+	// Encoders is the configuration of the console and JSON encoders
+	Encoders EncodersConfig
+	// Writers is the set of writers for loggers to write to, keyed by writer name.
+	// The "stdout" and "stderr" writers write to the standard streams, and all other writers write to files.
+	Writers map[string]WriterConfig
+	// RootLogger is the configuration of the root logger
+	RootLogger LoggerConfig
+	// Loggers is the configuration of descendants of the root logger, keyed by logger path or pattern
+	Loggers map[string]LoggerConfig
+}
+
+// EncodersConfig is the configuration of the console and JSON encoders
+type EncodersConfig struct {
+	Console EncoderConfig
+	JSON    EncoderConfig
+}
+
+// EncoderConfig is the configuration of an encoder
+type EncoderConfig struct {
+	// Fields is the set of fields to include in each entry
+	Fields EncoderFieldsConfig
+}
+
+// EncoderFieldsConfig is the set of fields to include in each entry. Nil fields are excluded.
+type EncoderFieldsConfig struct {
+	Name       *FieldConfig
+	Message    *FieldConfig
+	Level      *LevelFieldConfig
+	Timestamp  *TimestampFieldConfig
+	Caller     *CallerFieldConfig
+	Stacktrace *FieldConfig
+	TraceID    *FieldConfig
+	SpanID     *FieldConfig
+	TraceFlags *FieldConfig
+}
+
+// FieldConfig is the configuration of an encoder field
+type FieldConfig struct {
+	// Key is the JSON key for the field. If empty, the framework's default key is used.
+	Key string
+}
+
+// LevelFieldConfig is the configuration of the level field
+type LevelFieldConfig struct {
+	FieldConfig
+	// Format is the level format. If empty, the framework's default format is used.
+	Format LevelFormat
+}
+
+// TimestampFieldConfig is the configuration of the timestamp field
+type TimestampFieldConfig struct {
+	FieldConfig
+	// Format is the timestamp format. If empty, the framework's default format is used.
+	Format TimestampFormat
+}
+
+// CallerFieldConfig is the configuration of the caller field
+type CallerFieldConfig struct {
+	FieldConfig
+	// Format is the caller format. If empty, the framework's default format is used.
+	Format CallerFormat
+}
+
+// WriterConfig is the configuration of a writer
+type WriterConfig struct {
+	// Encoder is the encoding of entries written by the writer
+	Encoder Encoding
+	// Path is the path of the file to write to. The path is required for all writers except "stdout" and "stderr".
+	Path string
+}
+
+// LoggerConfig is the configuration of a logger
+type LoggerConfig struct {
+	// Level is the logger level. If empty, the level is inherited from the logger's ancestors.
+	Level Level
+	// Sample is the sampling configuration for the logger. If nil, sampling is inherited from the logger's ancestors.
+	Sample *SamplerConfig
+	// Outputs is the set of outputs added to the logger, keyed by writer name
+	Outputs map[string]OutputConfig
+}
+
+// OutputConfig is the configuration of a logger output
+type OutputConfig struct {
+	// Level is the output level. If empty, all entries written by the logger are written to the output.
+	Level Level
+	// Sample is the sampling configuration for the output
+	Sample *SamplerConfig
+}
+
+// SamplerConfig is the configuration of a sampler. Only one of Basic or Random may be set.
+type SamplerConfig struct {
+	Basic  *BasicSamplerConfig
+	Random *RandomSamplerConfig
+}
+
+// BasicSamplerConfig is the configuration of a sampler that writes every nth entry
+type BasicSamplerConfig struct {
+	// Interval is the number of entries per sampled entry
+	Interval int
+	// MaxLevel is the maximum level to sample. Entries above this level are always written.
+	MaxLevel Level
+}
+
+// RandomSamplerConfig is the configuration of a sampler that writes a random sample of entries
+type RandomSamplerConfig struct {
+	// Interval is the average number of entries per sampled entry
+	Interval int
+	// MaxLevel is the maximum level to sample. Entries above this level are always written.
+	MaxLevel Level
+}
 
-	if home, err := homedir.Dir(); err == nil {
-		bytes, err = os.ReadFile(filepath.Join(home, configFile))
-		if err == nil {
-			return yaml.Unmarshal(bytes, config)
-		} else if !os.IsNotExist(err) {
-			return err
+// loggingConfig converts the configuration to the internal logging configuration
+func (c Config) loggingConfig() (loggingConfig, error) {
+	config := loggingConfig{
+		Encoders: encodersConfig{
+			Console: encoderConfig{Fields: c.Encoders.Console.Fields.encoderFieldsConfig()},
+			JSON:    encoderConfig{Fields: c.Encoders.JSON.Fields.encoderFieldsConfig()},
+		},
+		Writers: writersConfig{
+			Files: make(map[string]fileWriterConfig),
+		},
+		Loggers: make(map[string]loggerConfig),
+	}
+
+	for name, writer := range c.Writers {
+		if writer.Encoder == "" {
+			return loggingConfig{}, fmt.Errorf("writer '%s' is missing required encoder", name)
+		}
+		switch name {
+		case "stdout":
+			config.Writers.Stdout = &stdoutWriterConfig{writerConfig{Encoder: writer.Encoder}}
+		case "stderr":
+			config.Writers.Stderr = &stderrWriterConfig{writerConfig{Encoder: writer.Encoder}}
+		default:
+			if writer.Path == "" {
+				return loggingConfig{}, fmt.Errorf("writer '%s' is missing required path", name)
+			}
+			config.Writers.Files[name] = fileWriterConfig{
+				writerConfig: writerConfig{Encoder: writer.Encoder},
+				Path:         writer.Path,
+			}
 		}
 	}
 
-	bytes, err = os.ReadFile(filepath.Join("/etc/dazl", configFile))
-	if err == nil {
-		return yaml.Unmarshal(bytes, config)
-	} else if !os.IsNotExist(err) {
-		return err
+	rootLogger, err := c.RootLogger.loggerConfig()
+	if err != nil {
+		return loggingConfig{}, fmt.Errorf("root logger: %w", err)
+	}
+	config.RootLogger = rootLogger
+
+	for name, logger := range c.Loggers {
+		loggerConfig, err := logger.loggerConfig()
+		if err != nil {
+			return loggingConfig{}, fmt.Errorf("logger '%s': %w", name, err)
+		}
+		config.Loggers[name] = loggerConfig
+	}
+	return config, nil
+}
+
+func (c EncoderFieldsConfig) encoderFieldsConfig() encoderFieldsConfig {
+	var config encoderFieldsConfig
+	if c.Name != nil {
+		config.Name = &nameEncoderConfig{fieldEncoderConfig{Key: c.Name.Key}}
+	}
+	if c.Message != nil {
+		config.Message = &messageEncoderConfig{fieldEncoderConfig{Key: c.Message.Key}}
+	}
+	if c.Level != nil {
+		config.Level = &levelEncoderConfig{fieldEncoderConfig: fieldEncoderConfig{Key: c.Level.Key}}
+		if c.Level.Format != "" {
+			format := c.Level.Format
+			config.Level.Format = &format
+		}
+	}
+	if c.Timestamp != nil {
+		config.Time = &timestampEncoderConfig{fieldEncoderConfig: fieldEncoderConfig{Key: c.Timestamp.Key}}
+		if c.Timestamp.Format != "" {
+			format := c.Timestamp.Format
+			config.Time.Format = &format
+		}
+	}
+	if c.Caller != nil {
+		config.Caller = &callerEncoderConfig{fieldEncoderConfig: fieldEncoderConfig{Key: c.Caller.Key}}
+		if c.Caller.Format != "" {
+			format := c.Caller.Format
+			config.Caller.Format = &format
+		}
+	}
+	if c.Stacktrace != nil {
+		config.Stacktrace = &stacktraceEncoderConfig{fieldEncoderConfig{Key: c.Stacktrace.Key}}
+	}
+	if c.TraceID != nil {
+		config.TraceID = &traceIDEncoderConfig{fieldEncoderConfig{Key: c.TraceID.Key}}
+	}
+	if c.SpanID != nil {
+		config.SpanID = &spanIDEncoderConfig{fieldEncoderConfig{Key: c.SpanID.Key}}
+	}
+	if c.TraceFlags != nil {
+		config.TraceFlags = &traceFlagsEncoderConfig{fieldEncoderConfig{Key: c.TraceFlags.Key}}
+	}
+	return config
+}
+
+func (c LoggerConfig) loggerConfig() (loggerConfig, error) {
+	sample, err := c.Sample.samplingConfig()
+	if err != nil {
+		return loggerConfig{}, err
+	}
+	config := loggerConfig{
+		Level:  levelConfig(c.Level),
+		Sample: sample,
+		Outputs: outputsConfig{
+			Outputs: make(map[string]outputSchema),
+		},
+	}
+	for name, output := range c.Outputs {
+		sample, err := output.Sample.samplingConfig()
+		if err != nil {
+			return loggerConfig{}, fmt.Errorf("output '%s': %w", name, err)
+		}
+		config.Outputs.Outputs[name] = outputSchema{
+			Level:  levelConfig(output.Level),
+			Sample: sample,
+		}
+	}
+	return config, nil
+}
+
+func (c *SamplerConfig) samplingConfig() (samplingConfig, error) {
+	var config samplingConfig
+	if c == nil {
+		return config, nil
+	}
+	if c.Basic != nil && c.Random != nil {
+		return config, errors.New("only one of basic or random sampling may be configured")
+	}
+	if (c.Basic != nil && c.Basic.Interval <= 0) || (c.Random != nil && c.Random.Interval <= 0) {
+		return config, errors.New("sampling interval must be a positive integer")
+	}
+	if c.Basic != nil {
+		config.Basic = &basicSamplerConfig{
+			samplerConfig: samplerConfig{MaxLevel: levelConfig(c.Basic.MaxLevel)},
+			Interval:      c.Basic.Interval,
+		}
+	}
+	if c.Random != nil {
+		config.Random = &randomSamplerConfig{
+			samplerConfig: samplerConfig{MaxLevel: levelConfig(c.Random.MaxLevel)},
+			Interval:      c.Random.Interval,
+		}
 	}
-	return nil
+	return config, nil
 }
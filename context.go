@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import "context"
+
+type contextFieldsKey struct{}
+
+// ContextWithFields returns a copy of the given context carrying the given fields in addition to
+// any fields already carried by the context. Fields carried by a context are added to entries
+// written by a Logger returned by WithContext.
+func ContextWithFields(ctx context.Context, fields ...Field) context.Context {
+	if len(fields) == 0 {
+		return ctx
+	}
+	parent := FieldsFromContext(ctx)
+	children := make([]Field, 0, len(parent)+len(fields))
+	children = append(children, parent...)
+	children = append(children, fields...)
+	return context.WithValue(ctx, contextFieldsKey{}, children)
+}
+
+// FieldsFromContext returns the fields carried by the given context
+func FieldsFromContext(ctx context.Context) []Field {
+	if ctx == nil {
+		return nil
+	}
+	fields, _ := ctx.Value(contextFieldsKey{}).([]Field)
+	return fields
+}
@@ -0,0 +1,60 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io"
+	"testing"
+)
+
+const testContextConfig = `
+encoders:
+  json:
+    fields:
+      - message
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+`
+
+func TestContextWithFields(t *testing.T) {
+	defer resetRootLogger()()
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testContextConfig), &config))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+
+	ctx := context.Background()
+	assert.Empty(t, FieldsFromContext(ctx))
+	assert.Equal(t, ctx, ContextWithFields(ctx))
+
+	GetRootLogger().WithContext(ctx).Info("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	parent := ContextWithFields(ctx, String("request-id", "1234"))
+	child := ContextWithFields(parent, String("tenant-id", "foo"), Int("user-id", 5678))
+	assert.Len(t, FieldsFromContext(parent), 1)
+	assert.Len(t, FieldsFromContext(child), 3)
+
+	GetRootLogger().WithContext(parent).Info("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\",\"request-id\":\"1234\"}\n", buf.String())
+	buf.Reset()
+
+	GetLogger("foo").WithContext(child).Infow("Hello world!", Bool("bar", true))
+	assert.Equal(t, "{\"message\":\"Hello world!\",\"request-id\":\"1234\",\"tenant-id\":\"foo\",\"user-id\":5678,\"bar\":true}\n", buf.String())
+}
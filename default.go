@@ -0,0 +1,577 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultNameKey       = "logger"
+	defaultMessageKey    = "message"
+	defaultLevelKey      = "level"
+	defaultTimestampKey  = "time"
+	defaultCallerKey     = "caller"
+	defaultStacktraceKey = "trace"
+)
+
+// defaultEncoderConfig is the configuration for the built-in encoders.
+// An empty key indicates the field is disabled.
+type defaultEncoderConfig struct {
+	nameKey         string
+	messageKey      string
+	levelKey        string
+	levelFormat     LevelFormat
+	timestampKey    string
+	timestampFormat TimestampFormat
+	callerKey       string
+	callerFormat    CallerFormat
+	stacktraceKey   string
+}
+
+type defaultEncoder struct {
+	config  defaultEncoderConfig
+	newFunc func(defaultEncoderConfig) Encoder
+}
+
+func (e *defaultEncoder) with(f func(*defaultEncoderConfig)) Encoder {
+	config := e.config
+	f(&config)
+	return e.newFunc(config)
+}
+
+func (e *defaultEncoder) WithNameEnabled() (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.nameKey = defaultNameKey
+	}), nil
+}
+
+func (e *defaultEncoder) WithLevelEnabled() (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.levelKey = defaultLevelKey
+		config.levelFormat = LowerCaseLevelFormat
+	}), nil
+}
+
+func (e *defaultEncoder) WithLevelFormat(format LevelFormat) (Encoder, error) {
+	switch format {
+	case LowerCaseLevelFormat, UpperCaseLevelFormat, LowerCaseColorLevelFormat, UpperCaseColorLevelFormat:
+		return e.with(func(config *defaultEncoderConfig) {
+			config.levelFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported level format '%s'", format)
+	}
+}
+
+func (e *defaultEncoder) WithTimestampEnabled() (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.timestampKey = defaultTimestampKey
+		config.timestampFormat = UnixTimestampFormat
+	}), nil
+}
+
+func (e *defaultEncoder) WithTimestampFormat(format TimestampFormat) (Encoder, error) {
+	switch format {
+	case ISO8601TimestampFormat, UnixTimestampFormat:
+		return e.with(func(config *defaultEncoderConfig) {
+			config.timestampFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported time format '%s'", format)
+	}
+}
+
+func (e *defaultEncoder) WithCallerEnabled() (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.callerKey = defaultCallerKey
+		config.callerFormat = ShortCallerFormat
+	}), nil
+}
+
+func (e *defaultEncoder) WithCallerFormat(format CallerFormat) (Encoder, error) {
+	switch format {
+	case ShortCallerFormat, FullCallerFormat:
+		return e.with(func(config *defaultEncoderConfig) {
+			config.callerFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported caller format '%s'", format)
+	}
+}
+
+func (e *defaultEncoder) WithStacktraceEnabled() (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.stacktraceKey = defaultStacktraceKey
+	}), nil
+}
+
+func newDefaultConsoleEncoder(config defaultEncoderConfig) Encoder {
+	return &defaultConsoleEncoder{
+		defaultEncoder: &defaultEncoder{
+			config:  config,
+			newFunc: newDefaultConsoleEncoder,
+		},
+	}
+}
+
+// defaultConsoleEncoder is the built-in console encoder
+type defaultConsoleEncoder struct {
+	*defaultEncoder
+}
+
+func (e *defaultConsoleEncoder) NewWriter(writer io.Writer) (Writer, error) {
+	return newDefaultWriter(writer, ConsoleEncoding, e.config), nil
+}
+
+var _ NameEncoder = (*defaultConsoleEncoder)(nil)
+var _ LevelEncoder = (*defaultConsoleEncoder)(nil)
+var _ LevelFormattingEncoder = (*defaultConsoleEncoder)(nil)
+var _ TimestampEncoder = (*defaultConsoleEncoder)(nil)
+var _ TimestampFormattingEncoder = (*defaultConsoleEncoder)(nil)
+var _ CallerEncoder = (*defaultConsoleEncoder)(nil)
+var _ CallerFormattingEncoder = (*defaultConsoleEncoder)(nil)
+var _ StacktraceEncoder = (*defaultConsoleEncoder)(nil)
+
+func newDefaultJSONEncoder(config defaultEncoderConfig) Encoder {
+	return &defaultJSONEncoder{
+		defaultEncoder: &defaultEncoder{
+			config:  config,
+			newFunc: newDefaultJSONEncoder,
+		},
+	}
+}
+
+// defaultJSONEncoder is the built-in JSON encoder
+type defaultJSONEncoder struct {
+	*defaultEncoder
+}
+
+func (e *defaultJSONEncoder) NewWriter(writer io.Writer) (Writer, error) {
+	return newDefaultWriter(writer, JSONEncoding, e.config), nil
+}
+
+func (e *defaultJSONEncoder) WithMessageKey(key string) (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.messageKey = key
+	}), nil
+}
+
+func (e *defaultJSONEncoder) WithNameKey(key string) (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.nameKey = key
+	}), nil
+}
+
+func (e *defaultJSONEncoder) WithLevelKey(key string) (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.levelKey = key
+	}), nil
+}
+
+func (e *defaultJSONEncoder) WithLevelFormat(format LevelFormat) (Encoder, error) {
+	switch format {
+	case LowerCaseLevelFormat, UpperCaseLevelFormat:
+		return e.with(func(config *defaultEncoderConfig) {
+			config.levelFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported level format '%s'", format)
+	}
+}
+
+func (e *defaultJSONEncoder) WithTimestampKey(key string) (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.timestampKey = key
+	}), nil
+}
+
+func (e *defaultJSONEncoder) WithCallerKey(key string) (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.callerKey = key
+	}), nil
+}
+
+func (e *defaultJSONEncoder) WithStacktraceKey(key string) (Encoder, error) {
+	return e.with(func(config *defaultEncoderConfig) {
+		config.stacktraceKey = key
+	}), nil
+}
+
+var _ MessageKeyEncoder = (*defaultJSONEncoder)(nil)
+var _ NameEncoder = (*defaultJSONEncoder)(nil)
+var _ NameKeyEncoder = (*defaultJSONEncoder)(nil)
+var _ LevelEncoder = (*defaultJSONEncoder)(nil)
+var _ LevelKeyEncoder = (*defaultJSONEncoder)(nil)
+var _ LevelFormattingEncoder = (*defaultJSONEncoder)(nil)
+var _ TimestampEncoder = (*defaultJSONEncoder)(nil)
+var _ TimestampKeyEncoder = (*defaultJSONEncoder)(nil)
+var _ TimestampFormattingEncoder = (*defaultJSONEncoder)(nil)
+var _ CallerEncoder = (*defaultJSONEncoder)(nil)
+var _ CallerKeyEncoder = (*defaultJSONEncoder)(nil)
+var _ CallerFormattingEncoder = (*defaultJSONEncoder)(nil)
+var _ StacktraceEncoder = (*defaultJSONEncoder)(nil)
+var _ StacktraceKeyEncoder = (*defaultJSONEncoder)(nil)
+
+// defaultSink serializes writes to the underlying io.Writer
+type defaultSink struct {
+	writer io.Writer
+	mu     sync.Mutex
+}
+
+func (s *defaultSink) write(bytes []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, _ = s.writer.Write(bytes)
+}
+
+type defaultField struct {
+	name  string
+	value any
+}
+
+func newDefaultWriter(writer io.Writer, encoding Encoding, config defaultEncoderConfig) *defaultWriter {
+	if config.messageKey == "" {
+		config.messageKey = defaultMessageKey
+	}
+	return &defaultWriter{
+		sink:     &defaultSink{writer: writer},
+		encoding: encoding,
+		config:   config,
+	}
+}
+
+// defaultWriter is the built-in Writer implementation
+type defaultWriter struct {
+	sink      *defaultSink
+	encoding  Encoding
+	config    defaultEncoderConfig
+	name      string
+	fields    []defaultField
+	skipCalls int
+}
+
+func (w *defaultWriter) WithName(name string) Writer {
+	return &defaultWriter{
+		sink:      w.sink,
+		encoding:  w.encoding,
+		config:    w.config,
+		name:      name,
+		fields:    w.fields,
+		skipCalls: w.skipCalls,
+	}
+}
+
+func (w *defaultWriter) WithSkipCalls(calls int) Writer {
+	return &defaultWriter{
+		sink:      w.sink,
+		encoding:  w.encoding,
+		config:    w.config,
+		name:      w.name,
+		fields:    w.fields,
+		skipCalls: w.skipCalls + calls,
+	}
+}
+
+func (w *defaultWriter) withField(name string, value any) Writer {
+	fields := make([]defaultField, len(w.fields), len(w.fields)+1)
+	copy(fields, w.fields)
+	return &defaultWriter{
+		sink:      w.sink,
+		encoding:  w.encoding,
+		config:    w.config,
+		name:      w.name,
+		fields:    append(fields, defaultField{name: name, value: value}),
+		skipCalls: w.skipCalls,
+	}
+}
+
+func (w *defaultWriter) WithErrorField(err error) Writer {
+	return w.withField("error", err.Error())
+}
+
+func (w *defaultWriter) WithStringField(name string, value string) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithBoolField(name string, value bool) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithIntField(name string, value int) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithInt32Field(name string, value int32) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithInt64Field(name string, value int64) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithUintField(name string, value uint) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithUint32Field(name string, value uint32) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithUint64Field(name string, value uint64) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithFloat32Field(name string, value float32) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithFloat64Field(name string, value float64) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithTimeField(name string, value time.Time) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithDurationField(name string, value time.Duration) Writer {
+	return w.withField(name, value.String())
+}
+
+func (w *defaultWriter) WithBinaryField(name string, value []byte) Writer {
+	return w.withField(name, value)
+}
+
+func (w *defaultWriter) WithBytesField(name string, value []byte) Writer {
+	return w.withField(name, string(value))
+}
+
+func (w *defaultWriter) WithStringSliceField(name string, values []string) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithBoolSliceField(name string, values []bool) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithIntSliceField(name string, values []int) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithInt32SliceField(name string, values []int32) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithInt64SliceField(name string, values []int64) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithUintSliceField(name string, values []uint) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithUint32SliceField(name string, values []uint32) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithUint64SliceField(name string, values []uint64) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithFloat32SliceField(name string, values []float32) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithFloat64SliceField(name string, values []float64) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithTimeSliceField(name string, values []time.Time) Writer {
+	return w.withField(name, values)
+}
+
+func (w *defaultWriter) WithDurationSliceField(name string, values []time.Duration) Writer {
+	strs := make([]string, len(values))
+	for i, value := range values {
+		strs[i] = value.String()
+	}
+	return w.withField(name, strs)
+}
+
+func (w *defaultWriter) Trace(msg string) {
+	w.log(TraceLevel, msg)
+}
+
+func (w *defaultWriter) Debug(msg string) {
+	w.log(DebugLevel, msg)
+}
+
+func (w *defaultWriter) Info(msg string) {
+	w.log(InfoLevel, msg)
+}
+
+func (w *defaultWriter) Warn(msg string) {
+	w.log(WarnLevel, msg)
+}
+
+func (w *defaultWriter) Error(msg string) {
+	w.log(ErrorLevel, msg)
+}
+
+func (w *defaultWriter) Fatal(msg string) {
+	w.log(FatalLevel, msg)
+	os.Exit(1)
+}
+
+func (w *defaultWriter) Panic(msg string) {
+	w.log(PanicLevel, msg)
+	panic(msg)
+}
+
+func (w *defaultWriter) Log(level Level, msg string) {
+	w.log(level, msg)
+}
+
+// log encodes and writes an entry, attributing it to the caller of the Writer method
+func (w *defaultWriter) log(level Level, msg string) {
+	entry := defaultEntry{
+		time:    time.Now(),
+		level:   level,
+		message: msg,
+	}
+	if w.config.callerKey != "" {
+		// Skip log and the Writer method
+		if _, file, line, ok := runtime.Caller(2 + w.skipCalls); ok {
+			entry.caller = FormatCaller(file, line, w.config.callerFormat)
+		}
+	}
+	if w.config.stacktraceKey != "" && ErrorLevel.Enabled(level) {
+		// Skip runtime.Callers, stacktrace, log, and the Writer method
+		entry.stacktrace = Stacktrace(4 + w.skipCalls)
+	}
+
+	buf := &bytes.Buffer{}
+	switch w.encoding {
+	case JSONEncoding:
+		w.encodeJSON(buf, entry)
+	default:
+		w.encodeConsole(buf, entry)
+	}
+	w.sink.write(buf.Bytes())
+}
+
+type defaultEntry struct {
+	time       time.Time
+	level      Level
+	message    string
+	caller     string
+	stacktrace string
+}
+
+func (w *defaultWriter) encodeJSON(buf *bytes.Buffer, entry defaultEntry) {
+	buf.WriteByte('{')
+	if w.config.levelKey != "" {
+		writeJSONField(buf, w.config.levelKey, w.formatLevel(entry.level))
+	}
+	if w.config.timestampKey != "" {
+		writeJSONField(buf, w.config.timestampKey, w.formatTimestamp(entry.time))
+	}
+	if w.config.nameKey != "" && w.name != "" {
+		writeJSONField(buf, w.config.nameKey, w.name)
+	}
+	if entry.caller != "" {
+		writeJSONField(buf, w.config.callerKey, entry.caller)
+	}
+	writeJSONField(buf, w.config.messageKey, entry.message)
+	for _, field := range w.fields {
+		writeJSONField(buf, field.name, field.value)
+	}
+	if entry.stacktrace != "" {
+		writeJSONField(buf, w.config.stacktraceKey, entry.stacktrace)
+	}
+	buf.WriteString("}\n")
+}
+
+func (w *defaultWriter) encodeConsole(buf *bytes.Buffer, entry defaultEntry) {
+	var elements []string
+	if w.config.timestampKey != "" {
+		switch timestamp := w.formatTimestamp(entry.time).(type) {
+		case float64:
+			elements = append(elements, strconv.FormatFloat(timestamp, 'f', -1, 64))
+		default:
+			elements = append(elements, fmt.Sprint(timestamp))
+		}
+	}
+	if w.config.levelKey != "" {
+		elements = append(elements, w.formatLevel(entry.level))
+	}
+	if w.config.nameKey != "" && w.name != "" {
+		elements = append(elements, w.name)
+	}
+	if entry.caller != "" {
+		elements = append(elements, entry.caller)
+	}
+	elements = append(elements, entry.message)
+	buf.WriteString(strings.Join(elements, "\t"))
+	if len(w.fields) > 0 {
+		buf.WriteString("\t{")
+		for _, field := range w.fields {
+			writeJSONField(buf, field.name, field.value)
+		}
+		buf.WriteByte('}')
+	}
+	if entry.stacktrace != "" {
+		buf.WriteByte('\n')
+		buf.WriteString(entry.stacktrace)
+	}
+	buf.WriteByte('\n')
+}
+
+func (w *defaultWriter) formatLevel(level Level) string {
+	switch w.config.levelFormat {
+	case UpperCaseLevelFormat:
+		return strings.ToUpper(level.String())
+	case LowerCaseColorLevelFormat:
+		return ColorLevel(level, level.String())
+	case UpperCaseColorLevelFormat:
+		return ColorLevel(level, strings.ToUpper(level.String()))
+	}
+	return level.String()
+}
+
+func (w *defaultWriter) formatTimestamp(t time.Time) any {
+	if w.config.timestampFormat == ISO8601TimestampFormat {
+		return t.Format(ISO8601TimeLayout)
+	}
+	return float64(t.UnixNano()) / float64(time.Second)
+}
+
+// writeJSONField appends a JSON key/value pair to the object in buf
+func writeJSONField(buf *bytes.Buffer, name string, value any) {
+	if last := buf.Bytes()[buf.Len()-1]; last != '{' {
+		buf.WriteByte(',')
+	}
+	key, _ := json.Marshal(name)
+	buf.Write(key)
+	buf.WriteByte(':')
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		bytes, _ = json.Marshal(fmt.Sprint(value))
+	}
+	buf.Write(bytes)
+}
+
+var _ Writer = (*defaultWriter)(nil)
+var _ FieldWriter = (*defaultWriter)(nil)
+var _ ErrorFieldWriter = (*defaultWriter)(nil)
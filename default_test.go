@@ -0,0 +1,270 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestDefaultWriter(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer := newDefaultWriter(buf, JSONEncoding, defaultEncoderConfig{
+		levelKey: "level",
+	})
+
+	writer.Trace("Hello world!")
+	assert.Equal(t, "{\"level\":\"trace\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Debug("Hello world!")
+	assert.Equal(t, "{\"level\":\"debug\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Warn("Hello world!")
+	assert.Equal(t, "{\"level\":\"warn\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Error("Hello world!")
+	assert.Equal(t, "{\"level\":\"error\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	assert.Panics(t, func() {
+		writer.Panic("Hello world!")
+	})
+	assert.Equal(t, "{\"level\":\"panic\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	assert.NoError(t, RegisterLevel("notice", 35))
+	writer.Log(35, "Hello world!")
+	assert.Equal(t, "{\"level\":\"notice\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.WithErrorField(errors.New("bar")).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"error\":\"bar\"}\n", buf.String())
+	buf.Reset()
+
+	writer.WithStringField("foo", "bar").Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":\"bar\"}\n", buf.String())
+	buf.Reset()
+
+	writer.WithIntField("foo", 1).(IntFieldWriter).WithIntField("bar", 2).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":1,\"bar\":2}\n", buf.String())
+	buf.Reset()
+
+	writer.WithUint64Field("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":1}\n", buf.String())
+	buf.Reset()
+
+	writer.WithFloat32Field("foo", 1.5).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":1.5}\n", buf.String())
+	buf.Reset()
+
+	writer.WithBoolField("foo", true).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":true}\n", buf.String())
+	buf.Reset()
+
+	writer.WithDurationField("foo", time.Second).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":\"1s\"}\n", buf.String())
+	buf.Reset()
+
+	writer.WithTimeField("foo", time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":\"2023-01-01T00:00:00Z\"}\n", buf.String())
+	buf.Reset()
+
+	writer.WithBinaryField("foo", []byte("bar")).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":\"YmFy\"}\n", buf.String())
+	buf.Reset()
+
+	writer.WithBytesField("foo", []byte("bar")).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":\"bar\"}\n", buf.String())
+	buf.Reset()
+
+	writer.WithStringSliceField("foo", []string{"bar"}).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":[\"bar\"]}\n", buf.String())
+	buf.Reset()
+
+	writer.WithIntSliceField("foo", []int{1}).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":[1]}\n", buf.String())
+	buf.Reset()
+
+	writer.WithFloat64SliceField("foo", []float64{1}).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":[1]}\n", buf.String())
+	buf.Reset()
+
+	writer.WithBoolSliceField("foo", []bool{true}).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":[true]}\n", buf.String())
+	buf.Reset()
+
+	writer.WithDurationSliceField("foo", []time.Duration{time.Second}).Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":[\"1s\"]}\n", buf.String())
+	buf.Reset()
+}
+
+func TestDefaultJSONEncoder(t *testing.T) {
+	encoder := (&defaultFramework{}).JSONEncoder()
+	buf := &bytes.Buffer{}
+	writer, err := encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Debug("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(MessageKeyEncoder).WithMessageKey("msg")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(NameEncoder).WithNameEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.WithName("test").Error("Hello world!")
+	assert.Equal(t, "{\"logger\":\"test\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(NameKeyEncoder).WithNameKey("name")
+	assert.NoError(t, err)
+	encoder, err = encoder.(LevelEncoder).WithLevelEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(LevelKeyEncoder).WithLevelKey("lvl")
+	assert.NoError(t, err)
+	encoder, err = encoder.(LevelFormattingEncoder).WithLevelFormat(UpperCaseLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.WithName("test").Warn("Hello world!")
+	assert.Equal(t, "{\"lvl\":\"WARN\",\"name\":\"test\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(CallerEncoder).WithCallerEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(CallerKeyEncoder).WithCallerKey("call")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Regexp(t, `^\{"lvl":"INFO","call":"[^/]+/default_test.go:161","msg":"Hello world!"\}\n$`, buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(CallerFormattingEncoder).WithCallerFormat(FullCallerFormat)
+	assert.NoError(t, err)
+	encoder, err = encoder.(TimestampEncoder).WithTimestampEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(TimestampKeyEncoder).WithTimestampKey("ts")
+	assert.NoError(t, err)
+	encoder, err = encoder.(TimestampFormattingEncoder).WithTimestampFormat(ISO8601TimestampFormat)
+	assert.NoError(t, err)
+	encoder, err = encoder.(StacktraceEncoder).WithStacktraceEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(StacktraceKeyEncoder).WithStacktraceKey("stack")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	object := make(map[string]any)
+	assert.NoError(t, json.Unmarshal(buf.Bytes(), &object))
+	assert.Contains(t, object["call"], "/default_test.go:179")
+	assert.Contains(t, object, "ts")
+	assert.Contains(t, object["stack"], "TestDefaultJSONEncoder")
+	buf.Reset()
+}
+
+func TestDefaultConsoleEncoder(t *testing.T) {
+	encoder := (&defaultFramework{}).ConsoleEncoder()
+	buf := &bytes.Buffer{}
+	writer, err := encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Debug("Hello world!")
+	assert.Equal(t, "Hello world!\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(NameEncoder).WithNameEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.WithName("test").Warn("Hello world!")
+	assert.Equal(t, "test\tHello world!\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(LevelEncoder).WithLevelEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(LevelFormattingEncoder).WithLevelFormat(UpperCaseLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "INFO\tHello world!\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(CallerEncoder).WithCallerEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.(StringFieldWriter).WithStringField("foo", "bar").Warn("Hello world!")
+	assert.Regexp(t, `^WARN\t[^/]+/default_test.go:219\tHello world!\t\{"foo":"bar"\}\n$`, buf.String())
+	buf.Reset()
+
+	encoder, err = (&defaultFramework{}).ConsoleEncoder().(LevelEncoder).WithLevelEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(LevelFormattingEncoder).WithLevelFormat(UpperCaseColorLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assert.Equal(t, "\x1b[31mERROR\x1b[0m\tHello world!\n", buf.String())
+	buf.Reset()
+
+	_, err = (&defaultFramework{}).JSONEncoder().(LevelFormattingEncoder).WithLevelFormat(UpperCaseColorLevelFormat)
+	assert.Error(t, err)
+}
+
+const testDefaultConfig = `
+encoders:
+  json:
+    fields:
+      - message
+      - name
+      - level
+      - caller
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+`
+
+func TestDefaultFramework(t *testing.T) {
+	defer resetRootLogger()()
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testDefaultConfig), &config))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+
+	log := GetLogger("test")
+	log.Debug("Hello world!")
+	assert.Equal(t, "", buf.String())
+	log.Infow("Hello world!", String("foo", "bar"))
+	assert.Regexp(t, `^\{"level":"info","logger":"test","caller":"[^/]+/default_test.go:268","message":"Hello world!","foo":"bar"\}\n$`, buf.String())
+}
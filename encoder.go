@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"gopkg.in/yaml.v3"
 	"io"
+	"runtime"
+	"strconv"
 	"strings"
 )
 
@@ -327,6 +329,9 @@ type encoderFieldsConfig struct {
 	Time       *timestampEncoderConfig  `json:"timestamp" yaml:"timestamp"`
 	Caller     *callerEncoderConfig     `json:"caller" yaml:"caller"`
 	Stacktrace *stacktraceEncoderConfig `json:"stacktrace" yaml:"stacktrace"`
+	TraceID    *traceIDEncoderConfig    `json:"traceId" yaml:"traceId"`
+	SpanID     *spanIDEncoderConfig     `json:"spanId" yaml:"spanId"`
+	TraceFlags *traceFlagsEncoderConfig `json:"traceFlags" yaml:"traceFlags"`
 }
 
 func (c *encoderFieldsConfig) UnmarshalYAML(unmarshal func(any) error) error {
@@ -368,6 +373,21 @@ func (c *encoderFieldsConfig) UnmarshalYAML(unmarshal func(any) error) error {
 				if err := yaml.Unmarshal(bytes, c.Stacktrace); err != nil {
 					return err
 				}
+			case "traceId":
+				c.TraceID = &traceIDEncoderConfig{}
+				if err := yaml.Unmarshal(bytes, c.TraceID); err != nil {
+					return err
+				}
+			case "spanId":
+				c.SpanID = &spanIDEncoderConfig{}
+				if err := yaml.Unmarshal(bytes, c.SpanID); err != nil {
+					return err
+				}
+			case "traceFlags":
+				c.TraceFlags = &traceFlagsEncoderConfig{}
+				if err := yaml.Unmarshal(bytes, c.TraceFlags); err != nil {
+					return err
+				}
 			}
 		}
 		return nil
@@ -396,6 +416,15 @@ func (c *encoderFieldsConfig) UnmarshalYAML(unmarshal func(any) error) error {
 		if field.Stacktrace != nil {
 			c.Stacktrace = field.Stacktrace
 		}
+		if field.TraceID != nil {
+			c.TraceID = field.TraceID
+		}
+		if field.SpanID != nil {
+			c.SpanID = field.SpanID
+		}
+		if field.TraceFlags != nil {
+			c.TraceFlags = field.TraceFlags
+		}
 	}
 	return nil
 }
@@ -407,6 +436,9 @@ type encoderFieldSchema struct {
 	Time       *timestampEncoderConfig  `json:"timestamp" yaml:"timestamp"`
 	Caller     *callerEncoderConfig     `json:"caller" yaml:"caller"`
 	Stacktrace *stacktraceEncoderConfig `json:"stacktrace" yaml:"stacktrace"`
+	TraceID    *traceIDEncoderConfig    `json:"traceId" yaml:"traceId"`
+	SpanID     *spanIDEncoderConfig     `json:"spanId" yaml:"spanId"`
+	TraceFlags *traceFlagsEncoderConfig `json:"traceFlags" yaml:"traceFlags"`
 }
 
 func (c *encoderFieldSchema) UnmarshalYAML(unmarshal func(any) error) error {
@@ -446,6 +478,15 @@ func (c *encoderFieldSchema) UnmarshalYAML(unmarshal func(any) error) error {
 		case stacktraceFieldName:
 			c.Stacktrace = &stacktraceEncoderConfig{}
 			return yaml.Unmarshal(text, c.Stacktrace)
+		case traceIDFieldName:
+			c.TraceID = &traceIDEncoderConfig{}
+			return yaml.Unmarshal(text, c.TraceID)
+		case spanIDFieldName:
+			c.SpanID = &spanIDEncoderConfig{}
+			return yaml.Unmarshal(text, c.SpanID)
+		case traceFlagsFieldName:
+			c.TraceFlags = &traceFlagsEncoderConfig{}
+			return yaml.Unmarshal(text, c.TraceFlags)
 		default:
 			return fmt.Errorf("unknown field encoder '%s'", name)
 		}
@@ -468,6 +509,12 @@ func (c *encoderFieldSchema) UnmarshalText(text []byte) error {
 		c.Caller = &callerEncoderConfig{}
 	case stacktraceFieldName:
 		c.Stacktrace = &stacktraceEncoderConfig{}
+	case traceIDFieldName:
+		c.TraceID = &traceIDEncoderConfig{}
+	case spanIDFieldName:
+		c.SpanID = &spanIDEncoderConfig{}
+	case traceFlagsFieldName:
+		c.TraceFlags = &traceFlagsEncoderConfig{}
 	default:
 		return fmt.Errorf("unknown field encoder '%s'", name)
 	}
@@ -483,6 +530,9 @@ const (
 	timestampFieldName  fieldEncoderName = "timestamp"
 	callerFieldName     fieldEncoderName = "caller"
 	stacktraceFieldName fieldEncoderName = "stacktrace"
+	traceIDFieldName    fieldEncoderName = "traceId"
+	spanIDFieldName     fieldEncoderName = "spanId"
+	traceFlagsFieldName fieldEncoderName = "traceFlags"
 )
 
 type fieldEncoderConfig struct {
@@ -500,8 +550,10 @@ type messageEncoderConfig struct {
 type LevelFormat string
 
 const (
-	LowerCaseLevelFormat LevelFormat = "lowercase"
-	UpperCaseLevelFormat LevelFormat = "uppercase"
+	LowerCaseLevelFormat      LevelFormat = "lowercase"
+	UpperCaseLevelFormat      LevelFormat = "uppercase"
+	LowerCaseColorLevelFormat LevelFormat = "lowercase-color"
+	UpperCaseColorLevelFormat LevelFormat = "uppercase-color"
 )
 
 func (f LevelFormat) String() string {
@@ -515,12 +567,33 @@ func (f *LevelFormat) UnmarshalText(text []byte) error {
 		*f = LowerCaseLevelFormat
 	case UpperCaseLevelFormat.String():
 		*f = UpperCaseLevelFormat
+	case LowerCaseColorLevelFormat.String():
+		*f = LowerCaseColorLevelFormat
+	case UpperCaseColorLevelFormat.String():
+		*f = UpperCaseColorLevelFormat
 	default:
 		return fmt.Errorf("unknown level format '%s'", name)
 	}
 	return nil
 }
 
+// ColorLevel wraps the level name in the ANSI color of the level. Custom levels are colored like the
+// built-in level below them.
+func ColorLevel(level Level, name string) string {
+	var color int
+	switch {
+	case ErrorLevel.Enabled(level):
+		color = 31 // red
+	case WarnLevel.Enabled(level):
+		color = 33 // yellow
+	case InfoLevel.Enabled(level):
+		color = 34 // blue
+	default:
+		color = 35 // magenta
+	}
+	return fmt.Sprintf("\x1b[%dm%s\x1b[0m", color, name)
+}
+
 type levelEncoderConfig struct {
 	fieldEncoderConfig `json:",inline" yaml:",inline"`
 	Format             *LevelFormat `json:"format" yaml:"format"`
@@ -533,6 +606,9 @@ const (
 	UnixTimestampFormat    TimestampFormat = "unix"
 )
 
+// ISO8601TimeLayout is the time layout of timestamps in the ISO8601TimestampFormat
+const ISO8601TimeLayout = "2006-01-02T15:04:05.000Z0700"
+
 func (f TimestampFormat) String() string {
 	return string(f)
 }
@@ -579,6 +655,19 @@ func (f *CallerFormat) UnmarshalText(text []byte) error {
 	return nil
 }
 
+// FormatCaller formats the caller file and line in the given format
+func FormatCaller(file string, line int, format CallerFormat) string {
+	if format != FullCallerFormat {
+		// Trim the file path to the package directory and file name
+		if i := strings.LastIndexByte(file, '/'); i != -1 {
+			if j := strings.LastIndexByte(file[:i], '/'); j != -1 {
+				file = file[j+1:]
+			}
+		}
+	}
+	return file + ":" + strconv.Itoa(line)
+}
+
 type callerEncoderConfig struct {
 	fieldEncoderConfig `json:",inline" yaml:",inline"`
 	Format             *CallerFormat `json:"format" yaml:"format"`
@@ -587,3 +676,38 @@ type callerEncoderConfig struct {
 type stacktraceEncoderConfig struct {
 	fieldEncoderConfig `json:",inline" yaml:",inline"`
 }
+
+// Stacktrace formats the stack of the calling goroutine, skipping the given number of frames
+func Stacktrace(skip int) string {
+	pcs := make([]uintptr, 64)
+	n := runtime.Callers(skip, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	var sb strings.Builder
+	for {
+		frame, more := frames.Next()
+		if sb.Len() > 0 {
+			sb.WriteByte('\n')
+		}
+		sb.WriteString(frame.Function)
+		sb.WriteString("\n\t")
+		sb.WriteString(frame.File)
+		sb.WriteByte(':')
+		sb.WriteString(strconv.Itoa(frame.Line))
+		if !more {
+			break
+		}
+	}
+	return sb.String()
+}
+
+type traceIDEncoderConfig struct {
+	fieldEncoderConfig `json:",inline" yaml:",inline"`
+}
+
+type spanIDEncoderConfig struct {
+	fieldEncoderConfig `json:",inline" yaml:",inline"`
+}
+
+type traceFlagsEncoderConfig struct {
+	fieldEncoderConfig `json:",inline" yaml:",inline"`
+}
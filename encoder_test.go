@@ -7,6 +7,7 @@ package dazl
 import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
+	"strings"
 	"testing"
 )
 
@@ -19,6 +20,8 @@ console:
     timestamp:
       format: unix
     caller:
+    traceId:
+      key: trace-id
 json:
   fields:
     - message
@@ -28,6 +31,7 @@ json:
         key: timestamp
     - caller:
         format: full
+    - spanId
 `
 
 func TestUnmarshalEncoders(t *testing.T) {
@@ -45,6 +49,8 @@ func TestUnmarshalEncoders(t *testing.T) {
 	assert.Equal(t, "the-level", encoders.Console.Fields.Level.Key)
 	assert.Equal(t, "", encoders.Console.Fields.Time.Key)
 	assert.Equal(t, "", encoders.Console.Fields.Caller.Key)
+	assert.Equal(t, "trace-id", encoders.Console.Fields.TraceID.Key)
+	assert.Nil(t, encoders.Console.Fields.SpanID)
 
 	assert.Nil(t, encoders.Console.Fields.Level.Format)
 	assert.NotNil(t, encoders.Console.Fields.Time.Format)
@@ -62,6 +68,8 @@ func TestUnmarshalEncoders(t *testing.T) {
 	assert.Equal(t, "", encoders.JSON.Fields.Level.Key)
 	assert.Equal(t, "timestamp", encoders.JSON.Fields.Time.Key)
 	assert.Equal(t, "", encoders.JSON.Fields.Caller.Key)
+	assert.Nil(t, encoders.JSON.Fields.TraceID)
+	assert.NotNil(t, encoders.JSON.Fields.SpanID)
 
 	assert.NotNil(t, encoders.JSON.Fields.Level.Format)
 	assert.Nil(t, encoders.JSON.Fields.Time.Format)
@@ -69,3 +77,22 @@ func TestUnmarshalEncoders(t *testing.T) {
 	assert.Equal(t, LowerCaseLevelFormat, *encoders.JSON.Fields.Level.Format)
 	assert.Equal(t, FullCallerFormat, *encoders.JSON.Fields.Caller.Format)
 }
+
+func TestEncoderHelpers(t *testing.T) {
+	assert.Equal(t, "dazl/encoder.go:10", FormatCaller("/src/github.com/atomix/dazl/encoder.go", 10, ShortCallerFormat))
+	assert.Equal(t, "dazl/encoder.go:10", FormatCaller("/src/github.com/atomix/dazl/encoder.go", 10, ""))
+	assert.Equal(t, "/src/github.com/atomix/dazl/encoder.go:10", FormatCaller("/src/github.com/atomix/dazl/encoder.go", 10, FullCallerFormat))
+	assert.Equal(t, "encoder.go:10", FormatCaller("encoder.go", 10, ShortCallerFormat))
+
+	assert.Equal(t, "\x1b[35mtrace\x1b[0m", ColorLevel(TraceLevel, "trace"))
+	assert.Equal(t, "\x1b[35mDEBUG\x1b[0m", ColorLevel(DebugLevel, "DEBUG"))
+	assert.Equal(t, "\x1b[34minfo\x1b[0m", ColorLevel(InfoLevel, "info"))
+	assert.Equal(t, "\x1b[33mwarn\x1b[0m", ColorLevel(WarnLevel, "warn"))
+	assert.Equal(t, "\x1b[31merror\x1b[0m", ColorLevel(ErrorLevel, "error"))
+	assert.Equal(t, "\x1b[31mfatal\x1b[0m", ColorLevel(FatalLevel, "fatal"))
+	assert.Equal(t, "\x1b[33maudit\x1b[0m", ColorLevel(Level(45), "audit"))
+
+	stack := Stacktrace(2)
+	assert.True(t, strings.HasPrefix(stack, "github.com/atomix/dazl.TestEncoderHelpers\n\t"))
+	assert.Contains(t, stack, "encoder_test.go:")
+}
@@ -0,0 +1,84 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"regexp"
+	"strings"
+)
+
+const levelEnv = "DAZL_LEVEL"
+
+// envVarPattern matches ${VAR} and ${VAR:-default} references in configuration values
+var envVarPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?}`)
+
+// envLevels returns the logger levels set by the given environment variables, keyed by the environment name
+// of the logger. The DAZL_LEVEL variable sets the level of the root logger, which is keyed by the empty string,
+// and DAZL_LEVEL_<name> variables set the levels of descendant loggers, e.g. DAZL_LEVEL_github_com_atomix_foo
+// sets the level of the github.com/atomix/foo logger.
+func envLevels(environ []string) (map[string]Level, error) {
+	levels := make(map[string]Level)
+	for _, env := range environ {
+		key, value, ok := strings.Cut(env, "=")
+		if !ok || !strings.HasPrefix(key, levelEnv) {
+			continue
+		}
+		var name string
+		if key != levelEnv {
+			if !strings.HasPrefix(key, levelEnv+"_") {
+				continue
+			}
+			name = envName(strings.TrimPrefix(key, levelEnv+"_"))
+		}
+		level, ok := parseLevel(strings.ToLower(value))
+		if !ok {
+			return nil, fmt.Errorf("%s: unknown level '%s'", key, value)
+		}
+		levels[name] = level
+	}
+	return levels, nil
+}
+
+// envName returns the name of the given logger path as it appears in environment variables,
+// replacing all characters other than letters and digits with underscores
+func envName(path string) string {
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		if r >= 'A' && r <= 'Z' {
+			return r + 'a' - 'A'
+		}
+		return '_'
+	}, path)
+}
+
+// interpolate replaces ${VAR} and ${VAR:-default} references in the scalar values of the given node
+// with the values of environment variables
+func interpolate(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		if !envVarPattern.MatchString(node.Value) {
+			return
+		}
+		node.Value = envVarPattern.ReplaceAllStringFunc(node.Value, func(ref string) string {
+			match := envVarPattern.FindStringSubmatch(ref)
+			if value, ok := os.LookupEnv(match[1]); ok && value != "" {
+				return value
+			}
+			return match[3]
+		})
+		// Resolve the type of unquoted values from the interpolated value
+		if node.Style&(yaml.SingleQuotedStyle|yaml.DoubleQuotedStyle) == 0 {
+			node.Tag = ""
+		}
+		return
+	}
+	for _, child := range node.Content {
+		interpolate(child)
+	}
+}
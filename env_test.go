@@ -0,0 +1,92 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestEnvLevels(t *testing.T) {
+	levels, err := envLevels([]string{
+		"HOME=/root",
+		"DAZL_LEVEL=warn",
+		"DAZL_LEVEL_github_com_atomix_foo=debug",
+		"DAZL_LEVEL_BAR=ERROR",
+		"DAZL_LEVELS=info",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]Level{
+		"":                      WarnLevel,
+		"github_com_atomix_foo": DebugLevel,
+		"bar":                   ErrorLevel,
+	}, levels)
+
+	_, err = envLevels([]string{"DAZL_LEVEL=verbose"})
+	assert.Error(t, err)
+
+	assert.Equal(t, "github_com_atomix_foo", envName("github.com/atomix/foo"))
+	assert.Equal(t, "foo_bar_baz", envName("Foo-Bar_baz"))
+}
+
+const testEnvOverrideConfig = `
+encoders:
+  json:
+    fields:
+      - message
+writers:
+  stdout:
+    encoder: json
+  file:
+    encoder: json
+    path: ${TEST_DAZL_LOG_DIR:-/var/log}/app.log
+rootLogger:
+  level: ${TEST_DAZL_ROOT_LEVEL:-info}
+  sample:
+    basic:
+      interval: ${TEST_DAZL_INTERVAL}
+  outputs:
+    - ${TEST_DAZL_OUTPUT:-stdout}
+loggers:
+  foo:
+    level: info
+`
+
+func TestEnvOverrides(t *testing.T) {
+	defer resetRootLogger()()
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, configFile)
+	writeFile(t, path, testEnvOverrideConfig)
+
+	t.Setenv("TEST_DAZL_LOG_DIR", "/tmp/logs")
+	t.Setenv("TEST_DAZL_INTERVAL", "20")
+	var config loggingConfig
+	assert.NoError(t, loadFile(path, &config))
+	assert.Equal(t, "/tmp/logs/app.log", config.Writers.Files["file"].Path)
+	assert.Equal(t, InfoLevel, config.RootLogger.Level.Level())
+	assert.Equal(t, 20, config.RootLogger.Sample.Basic.Interval)
+	assert.Contains(t, config.RootLogger.Outputs.Outputs, "stdout")
+
+	t.Setenv("DAZL_LEVEL", "error")
+	t.Setenv("DAZL_LEVEL_foo_bar", "debug")
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+	assert.Equal(t, ErrorLevel, GetRootLogger().Level())
+	assert.Equal(t, InfoLevel, GetLogger("foo").Level())
+	assert.Equal(t, DebugLevel, GetLogger("foo/bar").Level())
+	assert.Equal(t, DebugLevel, GetLogger("foo/bar/baz").Level())
+	assert.Equal(t, ErrorLevel, GetLogger("bar").Level())
+
+	t.Setenv("DAZL_LEVEL", "verbose")
+	assert.Error(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+}
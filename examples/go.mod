@@ -9,6 +9,7 @@ require (
 
 require (
 	github.com/mitchellh/go-homedir v1.1.0 // indirect
+	github.com/pelletier/go-toml/v2 v2.0.5 // indirect
 	github.com/pkg/errors v0.9.1 // indirect
 	go.uber.org/atomic v1.7.0 // indirect
 	go.uber.org/multierr v1.6.0 // indirect
@@ -21,3 +22,7 @@ replace github.com/atomix/dazl => ../
 replace github.com/atomix/dazl/zap => ../zap
 
 replace github.com/atomix/dazl/zerolog => ../zerolog
+
+replace github.com/atomix/dazl/logrus => ../logrus
+
+replace github.com/atomix/dazl/slog => ../slog
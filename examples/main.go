@@ -12,6 +12,12 @@ import _ "github.com/atomix/dazl/zap"
 // To configure the zerolog logger, import the github.com/atomix/dazl/zerolog package
 //import _ "github.com/atomix/dazl/zerolog"
 
+// To configure the logrus logger, import the github.com/atomix/dazl/logrus package
+//import _ "github.com/atomix/dazl/logrus"
+
+// To configure the slog logger, import the github.com/atomix/dazl/slog package
+//import _ "github.com/atomix/dazl/slog"
+
 var log = dazl.GetPackageLogger()
 
 const projectName = "dazl"
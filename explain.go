@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+const rootLoggerSource = "rootLogger"
+
+// LoggerExplanation describes the effective configuration of a logger after inheritance from the root logger
+// and the logger's ancestors. Sources identify where each setting was configured, e.g. "rootLogger",
+// "loggers/github.com/atomix" or "DAZL_LEVEL_github_com_atomix".
+type LoggerExplanation struct {
+	// Name is the logger path
+	Name string
+	// Level is the effective logger level. If empty, entries at all levels are written.
+	Level Level
+	// LevelSource is where the level was configured, or empty if no level is configured
+	LevelSource string
+	// Sample is the effective sampling configuration, or nil if all entries are written
+	Sample *SamplerConfig
+	// SampleSource is where sampling was configured, or empty if sampling is not configured
+	SampleSource string
+	// Outputs are the writers the logger writes to, sorted by writer name
+	Outputs []OutputExplanation
+}
+
+// OutputExplanation describes the effective configuration of a logger output
+type OutputExplanation struct {
+	// Writer is the name of the writer
+	Writer string
+	// Encoder is the encoding of the writer
+	Encoder Encoding
+	// Path is the path of the file written by the writer, if any
+	Path string
+	// Source is where the output was added
+	Source string
+	// Level is the output level. If empty, all entries written by the logger are written to the output.
+	Level Level
+	// LevelSource is where the output level was configured, or empty if no level is configured
+	LevelSource string
+	// Sample is the output sampling configuration, or nil if all entries are written
+	Sample *SamplerConfig
+	// SampleSource is where the output sampling was configured, or empty if sampling is not configured
+	SampleSource string
+}
+
+// Explain describes the effective configuration of the logger with the given path using the configuration
+// merged from the files at the given paths, or the configuration loaded by default if no paths are given.
+// Level overrides set by environment variables are included.
+func Explain(name string, paths ...string) (*LoggerExplanation, error) {
+	inputs := fileInputs(paths)
+	if len(paths) == 0 {
+		var err error
+		if inputs, err = configInputs(); err != nil {
+			return nil, err
+		}
+	}
+	var config loggingConfig
+	if err := loadInputs(inputs, nil, &config); err != nil {
+		return nil, err
+	}
+	levels, err := envLevels(os.Environ())
+	if err != nil {
+		return nil, err
+	}
+	config.levels = levels
+	if config.patterns, err = newLoggerPatterns(config.Loggers); err != nil {
+		return nil, err
+	}
+	return explain(config, strings.Trim(name, pathSep)), nil
+}
+
+// explain follows the inheritance rules of newLoggerState from the root logger down to the named logger
+func explain(config loggingConfig, name string) *LoggerExplanation {
+	explanation := &LoggerExplanation{Name: name}
+	outputs := make(map[string]*OutputExplanation)
+	apply := func(loggerConfig loggerConfig, source, levelSource string) {
+		if level := loggerConfig.Level.Level(); level != EmptyLevel {
+			explanation.Level = level
+			explanation.LevelSource = levelSource
+		}
+		if sample := loggerConfig.Sample.samplerConfig(); sample != nil {
+			explanation.Sample = sample
+			explanation.SampleSource = source
+		}
+		for writerName, outputConfig := range loggerConfig.Outputs.Outputs {
+			output, ok := outputs[writerName]
+			if !ok {
+				output = &OutputExplanation{
+					Writer: writerName,
+					Source: source,
+				}
+				output.Encoder, output.Path = config.Writers.describe(writerName)
+				outputs[writerName] = output
+			}
+			if level := outputConfig.Level.Level(); level != EmptyLevel {
+				output.Level = level
+				output.LevelSource = source
+			}
+			if sample := outputConfig.Sample.samplerConfig(); sample != nil {
+				output.Sample = sample
+				output.SampleSource = source
+			}
+		}
+	}
+
+	levelSource := rootLoggerSource
+	if _, ok := config.levels[""]; ok {
+		levelSource = levelEnv
+	}
+	apply(config.getRootLogger(), rootLoggerSource, levelSource)
+
+	if name != "" {
+		var patterns []string
+		path := strings.Split(name, pathSep)
+		for i := range path {
+			ancestor := strings.Join(path[:i+1], pathSep)
+			loggerConfig, pattern, ok := config.getLogger(ancestor, patterns)
+			if !ok {
+				continue
+			}
+			source := fmt.Sprintf("loggers/%s", ancestor)
+			if pattern != "" {
+				patterns = append(patterns, pattern)
+				source = fmt.Sprintf("loggers/%s", pattern)
+			}
+			levelSource := source
+			if _, ok := config.levels[envName(ancestor)]; ok {
+				levelSource = fmt.Sprintf("%s_%s", levelEnv, envName(ancestor))
+			}
+			apply(loggerConfig, source, levelSource)
+		}
+	}
+
+	for _, output := range outputs {
+		explanation.Outputs = append(explanation.Outputs, *output)
+	}
+	sort.Slice(explanation.Outputs, func(i, j int) bool {
+		return explanation.Outputs[i].Writer < explanation.Outputs[j].Writer
+	})
+	return explanation
+}
+
+// describe returns the encoding and path of the named writer
+func (c *writersConfig) describe(name string) (Encoding, string) {
+	encoding, _ := c.getEncoding(name)
+	config, _ := c.getFile(name)
+	return encoding, config.Path
+}
+
+// samplerConfig returns the public sampling configuration, or nil if sampling is not configured
+func (c samplingConfig) samplerConfig() *SamplerConfig {
+	switch {
+	case c.Basic != nil:
+		return &SamplerConfig{
+			Basic: &BasicSamplerConfig{
+				Interval: c.Basic.Interval,
+				MaxLevel: c.Basic.MaxLevel.Level(),
+			},
+		}
+	case c.Random != nil:
+		return &SamplerConfig{
+			Random: &RandomSamplerConfig{
+				Interval: c.Random.Interval,
+				MaxLevel: c.Random.MaxLevel.Level(),
+			},
+		}
+	}
+	return nil
+}
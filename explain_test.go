@@ -0,0 +1,99 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+const testExplainConfig = `
+writers:
+  stdout:
+    encoder: console
+  file:
+    encoder: json
+    path: app.log
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+loggers:
+  foo:
+    level: warn
+    sample: basic
+  foo/bar:
+    outputs:
+      stdout:
+        level: error
+      file:
+        level: debug
+        sample:
+          random:
+            interval: 5
+  foo/bar/baz/qux:
+    level: debug
+`
+
+func TestExplain(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFile)
+	writeFile(t, path, testExplainConfig)
+
+	explanation, err := Explain("", path)
+	assert.NoError(t, err)
+	assert.Equal(t, &LoggerExplanation{
+		Level:       InfoLevel,
+		LevelSource: "rootLogger",
+		Outputs: []OutputExplanation{
+			{Writer: "stdout", Encoder: ConsoleEncoding, Source: "rootLogger"},
+		},
+	}, explanation)
+
+	explanation, err = Explain("foo/bar/baz", path)
+	assert.NoError(t, err)
+	assert.Equal(t, &LoggerExplanation{
+		Name:         "foo/bar/baz",
+		Level:        WarnLevel,
+		LevelSource:  "loggers/foo",
+		Sample:       &SamplerConfig{Basic: &BasicSamplerConfig{Interval: 10}},
+		SampleSource: "loggers/foo",
+		Outputs: []OutputExplanation{
+			{
+				Writer:       "file",
+				Encoder:      JSONEncoding,
+				Path:         "app.log",
+				Source:       "loggers/foo/bar",
+				Level:        DebugLevel,
+				LevelSource:  "loggers/foo/bar",
+				Sample:       &SamplerConfig{Random: &RandomSamplerConfig{Interval: 5}},
+				SampleSource: "loggers/foo/bar",
+			},
+			{
+				Writer:      "stdout",
+				Encoder:     ConsoleEncoding,
+				Source:      "rootLogger",
+				Level:       ErrorLevel,
+				LevelSource: "loggers/foo/bar",
+			},
+		},
+	}, explanation)
+
+	t.Setenv("DAZL_LEVEL", "error")
+	t.Setenv("DAZL_LEVEL_foo_bar", "debug")
+	explanation, err = Explain("/foo/bar/", path)
+	assert.NoError(t, err)
+	assert.Equal(t, "foo/bar", explanation.Name)
+	assert.Equal(t, DebugLevel, explanation.Level)
+	assert.Equal(t, "DAZL_LEVEL_foo_bar", explanation.LevelSource)
+
+	explanation, err = Explain("baz", path)
+	assert.NoError(t, err)
+	assert.Equal(t, ErrorLevel, explanation.Level)
+	assert.Equal(t, "DAZL_LEVEL", explanation.LevelSource)
+
+	_, err = Explain("foo", filepath.Join(t.TempDir(), configFile))
+	assert.Error(t, err)
+}
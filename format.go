@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"errors"
+	"fmt"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const configFormatEnv = "LOGGING_CONFIG_FORMAT"
+
+// configFormat is the format of a configuration file
+type configFormat string
+
+const (
+	yamlFormat configFormat = "yaml"
+	jsonFormat configFormat = "json"
+	tomlFormat configFormat = "toml"
+)
+
+// configFileFormat returns the format of the configuration file at the given path. The format of the file
+// named by LOGGING_CONFIG can be set with LOGGING_CONFIG_FORMAT; otherwise the format is detected from the
+// file extension, defaulting to YAML.
+func configFileFormat(path string) (configFormat, error) {
+	if path == os.Getenv(configEnv) {
+		if format, ok, err := envConfigFormat(); ok || err != nil {
+			return format, err
+		}
+	}
+	return extConfigFormat(path), nil
+}
+
+// envConfigFormat returns the format set by LOGGING_CONFIG_FORMAT, if any
+func envConfigFormat() (configFormat, bool, error) {
+	format := os.Getenv(configFormatEnv)
+	if format == "" {
+		return "", false, nil
+	}
+	switch configFormat(strings.ToLower(format)) {
+	case yamlFormat, "yml":
+		return yamlFormat, true, nil
+	case jsonFormat:
+		return jsonFormat, true, nil
+	case tomlFormat:
+		return tomlFormat, true, nil
+	default:
+		return "", false, fmt.Errorf("%s: unknown configuration format '%s'", configFormatEnv, format)
+	}
+}
+
+// extConfigFormat returns the format of the file at the given path detected from its extension, defaulting to YAML
+func extConfigFormat(path string) configFormat {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return jsonFormat
+	case ".toml":
+		return tomlFormat
+	default:
+		return yamlFormat
+	}
+}
+
+// format returns the format of the configuration input. The format of configuration content read from
+// the environment can be set with LOGGING_CONFIG_FORMAT, defaulting to YAML.
+func (i configInput) format() (configFormat, error) {
+	switch {
+	case i.fsys != nil:
+		return extConfigFormat(i.path), nil
+	case i.data != nil:
+		format, ok, err := envConfigFormat()
+		if !ok {
+			format = yamlFormat
+		}
+		return format, err
+	default:
+		return configFileFormat(i.path)
+	}
+}
+
+// parseConfig parses the given configuration file contents into a YAML node, returning nil if the file is empty.
+// JSON and TOML files are parsed into the same YAML node tree as YAML files, so all formats are merged,
+// validated and decoded by the same rules, and node positions refer to the original file.
+func parseConfig(path string, format configFormat, data []byte) (*yaml.Node, error) {
+	switch format {
+	case jsonFormat:
+		return parseJSON(path, data)
+	case tomlFormat:
+		return parseTOML(path, data)
+	default:
+		var document yaml.Node
+		if err := yaml.Unmarshal(data, &document); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if len(document.Content) == 0 {
+			return nil, nil
+		}
+		return document.Content[0], nil
+	}
+}
+
+// plainStyle clears the quoting style of the scalar values of the given node. JSON and TOML strings are always
+// quoted, so the type of values referencing environment variables is resolved from the interpolated value
+// as for unquoted YAML values.
+func plainStyle(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		node.Style = 0
+		return
+	}
+	for _, child := range node.Content {
+		plainStyle(child)
+	}
+}
+
+// parseJSON parses JSON with the YAML parser, since JSON is a subset of YAML
+func parseJSON(path string, data []byte) (*yaml.Node, error) {
+	node, err := parseConfig(path, yamlFormat, data)
+	if node == nil || err != nil {
+		return nil, err
+	}
+	plainStyle(node)
+	return node, nil
+}
+
+// parseTOML decodes TOML into a YAML node. The positions of keys and values are not available from the TOML
+// decoder, so only syntax errors are reported with a position in the file.
+func parseTOML(path string, data []byte) (*yaml.Node, error) {
+	var values map[string]any
+	if err := toml.Unmarshal(data, &values); err != nil {
+		var decodeError *toml.DecodeError
+		if errors.As(err, &decodeError) {
+			line, column := decodeError.Position()
+			return nil, fmt.Errorf("%s:%d:%d: %w", path, line, column, err)
+		}
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+	var node yaml.Node
+	if err := node.Encode(values); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	plainStyle(&node)
+	return &node, nil
+}
@@ -0,0 +1,198 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testFormatYAMLConfig = `
+encoders:
+  json:
+    fields:
+      - message
+      - level:
+          format: uppercase
+  console:
+    fields:
+      message: {}
+      caller:
+        format: short
+writers:
+  stdout:
+    encoder: json
+  file:
+    encoder: console
+    path: ${TEST_LOG_DIR:-/var/log}/app.log
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+loggers:
+  foo/bar:
+    level: debug
+    sample:
+      basic:
+        interval: ${TEST_SAMPLE_INTERVAL:-10}
+    outputs:
+      file:
+        level: warn
+`
+
+const testFormatJSONConfig = `{
+	"encoders": {
+		"json": {
+			"fields": ["message", {"level": {"format": "uppercase"}}]
+		},
+		"console": {
+			"fields": {"message": {}, "caller": {"format": "short"}}
+		}
+	},
+	"writers": {
+		"stdout": {"encoder": "json"},
+		"file": {"encoder": "console", "path": "${TEST_LOG_DIR:-/var/log}/app.log"}
+	},
+	"rootLogger": {
+		"level": "info",
+		"outputs": ["stdout"]
+	},
+	"loggers": {
+		"foo/bar": {
+			"level": "debug",
+			"sample": {"basic": {"interval": "${TEST_SAMPLE_INTERVAL:-10}"}},
+			"outputs": {"file": {"level": "warn"}}
+		}
+	}
+}
+`
+
+const testFormatTOMLConfig = `
+[encoders.json]
+fields = ["message", { level = { format = "uppercase" } }]
+
+[encoders.console.fields]
+message = {}
+caller.format = "short"
+
+[writers]
+stdout = { encoder = "json" }
+
+[writers.file]
+encoder = "console"
+path = "${TEST_LOG_DIR:-/var/log}/app.log"
+
+[rootLogger]
+level = "info"
+outputs = ["stdout"]
+
+[loggers."foo/bar"]
+level = "debug"
+sample.basic.interval = "${TEST_SAMPLE_INTERVAL:-10}"
+outputs.file.level = "warn"
+`
+
+func TestConfigFormats(t *testing.T) {
+	t.Setenv("TEST_SAMPLE_INTERVAL", "5")
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "logging.yml"), testFormatYAMLConfig)
+	writeFile(t, filepath.Join(dir, "logging.json"), testFormatJSONConfig)
+	writeFile(t, filepath.Join(dir, "logging.toml"), testFormatTOMLConfig)
+
+	var expected loggingConfig
+	assert.NoError(t, loadFiles([]string{filepath.Join(dir, "logging.yml")}, &defaultFramework{}, &expected))
+	assert.Equal(t, "/var/log/app.log", expected.Writers.Files["file"].Path)
+	assert.Equal(t, 5, expected.Loggers["foo/bar"].Sample.Basic.Interval)
+	assert.Equal(t, WarnLevel, expected.Loggers["foo/bar"].Outputs.Outputs["file"].Level.Level())
+
+	for _, name := range []string{"logging.json", "logging.toml"} {
+		var config loggingConfig
+		assert.NoError(t, loadFiles([]string{filepath.Join(dir, name)}, &defaultFramework{}, &config), name)
+		assert.Equal(t, expected, config, name)
+	}
+}
+
+func TestConfigFormatErrors(t *testing.T) {
+	dir := t.TempDir()
+	jsonPath := filepath.Join(dir, "logging.json")
+	writeFile(t, jsonPath, "{\n  \"rootLogger\": {\n    \"level\": \"loud\",\n    \"outputs\": [\"stdout\"]\n  }\n}\n")
+	tomlPath := filepath.Join(dir, "logging.toml")
+	writeFile(t, tomlPath, "[rootLogger]\nlevel = \"info\"\nlevels = \"debug\"\n\n[loggers.foo]\noutputs = [\"stderr\"]\n")
+
+	err := loadFiles([]string{jsonPath, tomlPath}, &defaultFramework{}, &loggingConfig{})
+	var configErrors ConfigErrors
+	assert.True(t, errors.As(err, &configErrors))
+	assert.Equal(t, []string{
+		jsonPath + ":4:17: root logger output references undefined writer 'stdout'",
+		tomlPath + ": unknown key 'levels' in root logger",
+		tomlPath + ": logger 'foo' output references undefined writer 'stderr'",
+	}, errorStrings(configErrors))
+
+	writeFile(t, jsonPath, "{\n  \"rootLogger\": {\n    \"level\" \"info\"\n  }\n}\n")
+	err = loadFile(jsonPath, &loggingConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), jsonPath+": yaml: ")
+
+	writeFile(t, tomlPath, "[rootLogger]\nlevel = info\n")
+	err = loadFile(tomlPath, &loggingConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), tomlPath+":2:")
+
+	writeFile(t, tomlPath, "[rootLogger]\nlevel = \"info\"\nlevel = \"debug\"\n")
+	err = loadFile(tomlPath, &loggingConfig{})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "key level is already defined")
+}
+
+func TestConfigFileFormat(t *testing.T) {
+	for path, format := range map[string]configFormat{
+		"logging.yaml": yamlFormat,
+		"logging.yml":  yamlFormat,
+		"logging.json": jsonFormat,
+		"logging.TOML": tomlFormat,
+		"logging":      yamlFormat,
+	} {
+		actual, err := configFileFormat(path)
+		assert.NoError(t, err)
+		assert.Equal(t, format, actual, path)
+	}
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "logging")
+	writeFile(t, path, `{"rootLogger": {"level": "warn"}}`)
+	t.Setenv(configEnv, path)
+	t.Setenv(configFormatEnv, "json")
+	format, err := configFileFormat(path)
+	assert.NoError(t, err)
+	assert.Equal(t, jsonFormat, format)
+	var config loggingConfig
+	assert.NoError(t, loadFile(path, &config))
+	assert.Equal(t, WarnLevel, config.RootLogger.Level.Level())
+
+	t.Setenv(configFormatEnv, "ini")
+	_, err = configFileFormat(path)
+	assert.Error(t, err)
+}
+
+func TestFindConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	path, err := findConfigFile(dir)
+	assert.NoError(t, err)
+	assert.Empty(t, path)
+
+	writeFile(t, filepath.Join(dir, "logging.toml"), testFormatTOMLConfig)
+	path, err = findConfigFile(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "logging.toml"), path)
+
+	writeFile(t, filepath.Join(dir, "logging.yml"), testFormatYAMLConfig)
+	path, err = findConfigFile(dir)
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, "logging.yml"), path)
+	assert.NoError(t, os.Remove(filepath.Join(dir, "logging.yml")))
+}
@@ -10,8 +10,11 @@ import (
 )
 
 func Register(framework Framework) {
+	if autoConfigureEnabled() {
+		autoConfigured.Store(true)
+	}
 	var config loggingConfig
-	if err := load(&config); err != nil {
+	if err := load(framework, &config); err != nil {
 		panic(err)
 	} else if err := configure(framework, config, open); err != nil {
 		panic(err)
@@ -46,3 +49,11 @@ type defaultFramework struct{}
 func (f *defaultFramework) Name() string {
 	return "default"
 }
+
+func (f *defaultFramework) ConsoleEncoder() Encoder {
+	return newDefaultConsoleEncoder(defaultEncoderConfig{})
+}
+
+func (f *defaultFramework) JSONEncoder() Encoder {
+	return newDefaultJSONEncoder(defaultEncoderConfig{})
+}
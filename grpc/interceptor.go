@@ -0,0 +1,162 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"github.com/atomix/dazl"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"io"
+	"sync"
+	"time"
+)
+
+const (
+	methodKey   = "grpc.method"
+	peerKey     = "grpc.peer"
+	codeKey     = "grpc.code"
+	durationKey = "grpc.duration"
+)
+
+// UnaryServerInterceptor returns a server interceptor that logs unary calls to the given Logger
+func UnaryServerInterceptor(logger dazl.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+		resp, err := handler(ctx, req)
+		logCall(logger, "Finished unary call", info.FullMethod, peerFromContext(ctx), err, time.Since(start))
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor returns a server interceptor that logs streaming calls to the given Logger
+func StreamServerInterceptor(logger dazl.Logger) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		start := time.Now()
+		err := handler(srv, stream)
+		logCall(logger, "Finished streaming call", info.FullMethod, peerFromContext(stream.Context()), err, time.Since(start))
+		return err
+	}
+}
+
+// UnaryClientInterceptor returns a client interceptor that logs unary calls to the given Logger
+func UnaryClientInterceptor(logger dazl.Logger) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		var p peer.Peer
+		start := time.Now()
+		err := invoker(ctx, method, req, reply, cc, append(opts, grpc.Peer(&p))...)
+		logCall(logger, "Finished unary call", method, peerAddr(&p), err, time.Since(start))
+		return err
+	}
+}
+
+// StreamClientInterceptor returns a client interceptor that logs streaming calls to the given Logger when the
+// stream is finished
+func StreamClientInterceptor(logger dazl.Logger) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		p := &peer.Peer{}
+		start := time.Now()
+		stream, err := streamer(ctx, desc, cc, method, append(opts, grpc.Peer(p))...)
+		if err != nil {
+			logCall(logger, "Finished streaming call", method, peerAddr(p), err, time.Since(start))
+			return nil, err
+		}
+		return &loggingClientStream{
+			ClientStream: stream,
+			logger:       logger,
+			desc:         desc,
+			method:       method,
+			peer:         p,
+			start:        start,
+		}, nil
+	}
+}
+
+// loggingClientStream is a client stream that logs the call when the stream is finished
+type loggingClientStream struct {
+	grpc.ClientStream
+	logger dazl.Logger
+	desc   *grpc.StreamDesc
+	method string
+	peer   *peer.Peer
+	start  time.Time
+	once   sync.Once
+}
+
+func (s *loggingClientStream) RecvMsg(m any) error {
+	err := s.ClientStream.RecvMsg(m)
+	switch {
+	case err == io.EOF:
+		s.finish(nil)
+	case err != nil:
+		s.finish(err)
+	case !s.desc.ServerStreams:
+		// Streams without server streaming are finished when the single response is received
+		s.finish(nil)
+	}
+	return err
+}
+
+// finish logs the call once the stream is finished
+func (s *loggingClientStream) finish(err error) {
+	s.once.Do(func() {
+		logCall(s.logger, "Finished streaming call", s.method, peerAddr(s.peer), err, time.Since(s.start))
+	})
+}
+
+// logCall logs a call at the level for its status code
+func logCall(logger dazl.Logger, msg string, method string, peer string, err error, duration time.Duration) {
+	code := status.Code(err)
+	if code == codes.Unknown {
+		code = status.FromContextError(err).Code()
+	}
+	fields := []dazl.Field{
+		dazl.String(methodKey, method),
+		dazl.String(peerKey, peer),
+		dazl.String(codeKey, code.String()),
+		dazl.Duration(durationKey, duration),
+	}
+	if err != nil {
+		fields = append(fields, dazl.Error(err))
+	}
+	switch codeToLevel(code) {
+	case dazl.InfoLevel:
+		logger.Infow(msg, fields...)
+	case dazl.WarnLevel:
+		logger.Warnw(msg, fields...)
+	default:
+		logger.Errorw(msg, fields...)
+	}
+}
+
+// codeToLevel returns the level at which calls with the given status code are logged
+func codeToLevel(code codes.Code) dazl.Level {
+	switch code {
+	case codes.OK, codes.Canceled, codes.InvalidArgument, codes.NotFound, codes.AlreadyExists, codes.Unauthenticated:
+		return dazl.InfoLevel
+	case codes.DeadlineExceeded, codes.PermissionDenied, codes.ResourceExhausted, codes.FailedPrecondition,
+		codes.Aborted, codes.OutOfRange, codes.Unavailable:
+		return dazl.WarnLevel
+	default:
+		return dazl.ErrorLevel
+	}
+}
+
+func peerFromContext(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok {
+		return ""
+	}
+	return peerAddr(p)
+}
+
+func peerAddr(p *peer.Peer) string {
+	if p.Addr == nil {
+		return ""
+	}
+	return p.Addr.String()
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"context"
+	"github.com/atomix/dazl"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"io"
+	"net"
+	"regexp"
+	"testing"
+)
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	entries := &[]string{}
+	logger := &testLogger{
+		writer: &testWriter{entries: entries},
+		level:  dazl.InfoLevel,
+	}
+	interceptor := UnaryServerInterceptor(logger)
+	info := &grpc.UnaryServerInfo{FullMethod: "/test.Service/Get"}
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5678},
+	})
+
+	resp, err := interceptor(ctx, "foo", info, func(ctx context.Context, req any) (any, error) {
+		return "bar", nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "bar", resp)
+	assert.Regexp(t, regexp.MustCompile(`^info \S+ Finished unary call grpc.method=/test.Service/Get grpc.peer=127.0.0.1:5678 grpc.code=OK grpc.duration=\S+$`), last(entries))
+
+	_, err = interceptor(ctx, "foo", info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	})
+	assert.Error(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^warn \S+ Finished unary call .* grpc.code=Unavailable grpc.duration=\S+ error=rpc error: code = Unavailable desc = unavailable$`), last(entries))
+
+	_, err = interceptor(ctx, "foo", info, func(ctx context.Context, req any) (any, error) {
+		return nil, status.Error(codes.Internal, "internal")
+	})
+	assert.Error(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^error \S+ Finished unary call .* grpc.code=Internal `), last(entries))
+}
+
+func TestStreamServerInterceptor(t *testing.T) {
+	entries := &[]string{}
+	logger := &testLogger{
+		writer: &testWriter{entries: entries},
+		level:  dazl.InfoLevel,
+	}
+	interceptor := StreamServerInterceptor(logger)
+	info := &grpc.StreamServerInfo{FullMethod: "/test.Service/Watch"}
+	stream := &testServerStream{ctx: context.Background()}
+
+	err := interceptor(nil, stream, info, func(srv any, stream grpc.ServerStream) error {
+		return context.Canceled
+	})
+	assert.Error(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^info \S+ Finished streaming call grpc.method=/test.Service/Watch grpc.peer= grpc.code=Canceled `), last(entries))
+}
+
+func TestUnaryClientInterceptor(t *testing.T) {
+	entries := &[]string{}
+	logger := &testLogger{
+		writer: &testWriter{entries: entries},
+		level:  dazl.InfoLevel,
+	}
+	interceptor := UnaryClientInterceptor(logger)
+	err := interceptor(context.Background(), "/test.Service/Get", "foo", nil, nil,
+		func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+			for _, opt := range opts {
+				if peerOpt, ok := opt.(grpc.PeerCallOption); ok {
+					peerOpt.PeerAddr.Addr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5678}
+				}
+			}
+			return status.Error(codes.NotFound, "not found")
+		})
+	assert.Error(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^info \S+ Finished unary call grpc.method=/test.Service/Get grpc.peer=127.0.0.1:5678 grpc.code=NotFound `), last(entries))
+}
+
+func TestStreamClientInterceptor(t *testing.T) {
+	entries := &[]string{}
+	logger := &testLogger{
+		writer: &testWriter{entries: entries},
+		level:  dazl.InfoLevel,
+	}
+	interceptor := StreamClientInterceptor(logger)
+	streamer := func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return &testClientStream{
+			responses: 2,
+			err:       io.EOF,
+			opts:      opts,
+		}, nil
+	}
+
+	// The call is logged when the stream is finished
+	stream, err := interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/test.Service/Watch", streamer)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.RecvMsg(nil))
+	assert.NoError(t, stream.RecvMsg(nil))
+	assert.Empty(t, *entries)
+	assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+	assert.Len(t, *entries, 1)
+	assert.Regexp(t, regexp.MustCompile(`^info \S+ Finished streaming call grpc.method=/test.Service/Watch grpc.peer=127.0.0.1:5678 grpc.code=OK grpc.duration=\S+$`), last(entries))
+	assert.Equal(t, io.EOF, stream.RecvMsg(nil))
+	assert.Len(t, *entries, 1)
+
+	// Streams without server streaming are finished when the response is received
+	stream, err = interceptor(context.Background(), &grpc.StreamDesc{ClientStreams: true}, nil, "/test.Service/Put", streamer)
+	assert.NoError(t, err)
+	assert.NoError(t, stream.RecvMsg(nil))
+	assert.Len(t, *entries, 2)
+	assert.Regexp(t, regexp.MustCompile(`^info \S+ Finished streaming call grpc.method=/test.Service/Put `), last(entries))
+
+	stream, err = interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/test.Service/Watch",
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return &testClientStream{err: status.Error(codes.Unavailable, "unavailable")}, nil
+		})
+	assert.NoError(t, err)
+	assert.Error(t, stream.RecvMsg(nil))
+	assert.Regexp(t, regexp.MustCompile(`^warn \S+ Finished streaming call .* grpc.code=Unavailable `), last(entries))
+
+	_, err = interceptor(context.Background(), &grpc.StreamDesc{ServerStreams: true}, nil, "/test.Service/Watch",
+		func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return nil, status.Error(codes.Internal, "internal")
+		})
+	assert.Error(t, err)
+	assert.Regexp(t, regexp.MustCompile(`^error \S+ Finished streaming call .* grpc.code=Internal `), last(entries))
+}
+
+// testClientStream is a client stream that receives a number of responses, then fails with an error
+type testClientStream struct {
+	grpc.ClientStream
+	responses int
+	err       error
+	opts      []grpc.CallOption
+}
+
+func (s *testClientStream) RecvMsg(m any) error {
+	if s.responses > 0 {
+		s.responses--
+		return nil
+	}
+	// The peer is set when the stream is finished
+	for _, opt := range s.opts {
+		if peerOpt, ok := opt.(grpc.PeerCallOption); ok {
+			peerOpt.PeerAddr.Addr = &net.TCPAddr{IP: net.IPv4(127, 0, 0, 1), Port: 5678}
+		}
+	}
+	return s.err
+}
+
+type testServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *testServerStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"fmt"
+	"github.com/atomix/dazl"
+	"google.golang.org/grpc/grpclog"
+	"strings"
+)
+
+// NewLoggerV2 returns a grpclog.LoggerV2 that writes to the given dazl Logger.
+// Verbosity level 0 is enabled when the Logger is enabled for the info level, level 1 when the
+// Logger is enabled for the debug level, and higher levels when it's enabled for the trace level.
+func NewLoggerV2(logger dazl.Logger) grpclog.LoggerV2 {
+	return &LoggerV2{
+		logger: logger,
+	}
+}
+
+// LoggerV2 is a grpclog.LoggerV2 that writes to a dazl Logger
+type LoggerV2 struct {
+	logger dazl.Logger
+}
+
+// withDepth returns the Logger attributing entries to the given number of frames above the caller
+// of the grpclog function that called the LoggerV2
+func (l *LoggerV2) withDepth(depth int) dazl.Logger {
+	// Skip the LoggerV2 method and the grpclog function
+	return l.logger.WithSkipCalls(depth + 2)
+}
+
+func (l *LoggerV2) Info(args ...any) {
+	l.withDepth(0).Info(args...)
+}
+
+func (l *LoggerV2) Infoln(args ...any) {
+	l.withDepth(0).Info(sprintln(args...))
+}
+
+func (l *LoggerV2) Infof(format string, args ...any) {
+	l.withDepth(0).Infof(format, args...)
+}
+
+func (l *LoggerV2) InfoDepth(depth int, args ...any) {
+	l.withDepth(depth).Info(sprintln(args...))
+}
+
+func (l *LoggerV2) Warning(args ...any) {
+	l.withDepth(0).Warn(args...)
+}
+
+func (l *LoggerV2) Warningln(args ...any) {
+	l.withDepth(0).Warn(sprintln(args...))
+}
+
+func (l *LoggerV2) Warningf(format string, args ...any) {
+	l.withDepth(0).Warnf(format, args...)
+}
+
+func (l *LoggerV2) WarningDepth(depth int, args ...any) {
+	l.withDepth(depth).Warn(sprintln(args...))
+}
+
+func (l *LoggerV2) Error(args ...any) {
+	l.withDepth(0).Error(args...)
+}
+
+func (l *LoggerV2) Errorln(args ...any) {
+	l.withDepth(0).Error(sprintln(args...))
+}
+
+func (l *LoggerV2) Errorf(format string, args ...any) {
+	l.withDepth(0).Errorf(format, args...)
+}
+
+func (l *LoggerV2) ErrorDepth(depth int, args ...any) {
+	l.withDepth(depth).Error(sprintln(args...))
+}
+
+func (l *LoggerV2) Fatal(args ...any) {
+	l.withDepth(0).Fatal(args...)
+}
+
+func (l *LoggerV2) Fatalln(args ...any) {
+	l.withDepth(0).Fatal(sprintln(args...))
+}
+
+func (l *LoggerV2) Fatalf(format string, args ...any) {
+	l.withDepth(0).Fatalf(format, args...)
+}
+
+func (l *LoggerV2) FatalDepth(depth int, args ...any) {
+	l.withDepth(depth).Fatal(sprintln(args...))
+}
+
+func (l *LoggerV2) V(level int) bool {
+	switch {
+	case level <= 0:
+		return l.logger.Level().Enabled(dazl.InfoLevel)
+	case level == 1:
+		return l.logger.Level().Enabled(dazl.DebugLevel)
+	default:
+		return l.logger.Level().Enabled(dazl.TraceLevel)
+	}
+}
+
+var _ grpclog.LoggerV2 = (*LoggerV2)(nil)
+var _ grpclog.DepthLoggerV2 = (*LoggerV2)(nil)
+
+// sprintln formats the arguments in the manner of fmt.Println without the trailing newline
+func sprintln(args ...any) string {
+	return strings.TrimSuffix(fmt.Sprintln(args...), "\n")
+}
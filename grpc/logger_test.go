@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package grpc
+
+import (
+	"fmt"
+	"github.com/atomix/dazl"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/grpclog"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoggerV2(t *testing.T) {
+	entries := &[]string{}
+	logger := &testLogger{
+		writer: &testWriter{entries: entries},
+		level:  dazl.InfoLevel,
+	}
+	grpclog.SetLoggerV2(NewLoggerV2(logger))
+
+	grpclog.Info("Hello", "world!")
+	assert.Equal(t, "info logger_test.go:27 Helloworld!", last(entries))
+
+	grpclog.Infoln("Hello", "world!")
+	assert.Equal(t, "info logger_test.go:30 Hello world!", last(entries))
+
+	grpclog.Warningf("Hello %s!", "world")
+	assert.Equal(t, "warn logger_test.go:33 Hello world!", last(entries))
+
+	grpclog.Errorln("Hello", "world!")
+	assert.Equal(t, "error logger_test.go:36 Hello world!", last(entries))
+
+	grpclog.Component("test").Warning("Hello world!")
+	assert.Equal(t, "warn logger_test.go:39 [test] Hello world!", last(entries))
+
+	assert.True(t, grpclog.V(0))
+	assert.False(t, grpclog.V(1))
+	logger.level = dazl.DebugLevel
+	assert.True(t, grpclog.V(1))
+	assert.False(t, grpclog.V(2))
+	logger.level = dazl.TraceLevel
+	assert.True(t, grpclog.V(2))
+	logger.level = dazl.WarnLevel
+	assert.False(t, grpclog.V(0))
+}
+
+func last(entries *[]string) string {
+	if len(*entries) == 0 {
+		return ""
+	}
+	return (*entries)[len(*entries)-1]
+}
+
+// testLogger is a minimal dazl.Logger that writes to a single writer
+type testLogger struct {
+	dazl.Logger
+	writer dazl.Writer
+	level  dazl.Level
+}
+
+func (l *testLogger) Level() dazl.Level {
+	return l.level
+}
+
+func (l *testLogger) WithFields(fields ...dazl.Field) dazl.Logger {
+	writer := l.writer
+	for _, field := range fields {
+		var err error
+		if writer, err = field(writer); err != nil {
+			panic(err)
+		}
+	}
+	return &testLogger{
+		writer: writer,
+		level:  l.level,
+	}
+}
+
+func (l *testLogger) WithSkipCalls(calls int) dazl.Logger {
+	return &testLogger{
+		writer: l.writer.WithSkipCalls(calls),
+		level:  l.level,
+	}
+}
+
+func (l *testLogger) Info(args ...any) {
+	if l.level.Enabled(dazl.InfoLevel) {
+		l.writer.Info(fmt.Sprint(args...))
+	}
+}
+
+func (l *testLogger) Infow(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).Info(msg)
+}
+
+func (l *testLogger) Warn(args ...any) {
+	if l.level.Enabled(dazl.WarnLevel) {
+		l.writer.Warn(fmt.Sprint(args...))
+	}
+}
+
+func (l *testLogger) Warnf(format string, args ...any) {
+	if l.level.Enabled(dazl.WarnLevel) {
+		l.writer.Warn(fmt.Sprintf(format, args...))
+	}
+}
+
+func (l *testLogger) Warnw(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).Warn(msg)
+}
+
+func (l *testLogger) Error(args ...any) {
+	if l.level.Enabled(dazl.ErrorLevel) {
+		l.writer.Error(fmt.Sprint(args...))
+	}
+}
+
+func (l *testLogger) Errorw(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).Error(msg)
+}
+
+// testWriter is a dazl.Writer that records entries as strings
+type testWriter struct {
+	entries   *[]string
+	fields    []string
+	skipCalls int
+}
+
+func (w *testWriter) WithName(name string) dazl.Writer {
+	return w
+}
+
+func (w *testWriter) WithSkipCalls(calls int) dazl.Writer {
+	return &testWriter{
+		entries:   w.entries,
+		fields:    w.fields,
+		skipCalls: w.skipCalls + calls,
+	}
+}
+
+func (w *testWriter) withField(name string, value any) dazl.Writer {
+	fields := make([]string, 0, len(w.fields)+1)
+	fields = append(fields, w.fields...)
+	fields = append(fields, fmt.Sprintf("%s=%v", name, value))
+	return &testWriter{
+		entries:   w.entries,
+		fields:    fields,
+		skipCalls: w.skipCalls,
+	}
+}
+
+func (w *testWriter) WithErrorField(err error) dazl.Writer {
+	return w.withField("error", err.Error())
+}
+
+func (w *testWriter) WithStringField(name string, value string) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) WithDurationField(name string, value time.Duration) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) Trace(msg string) {
+	w.log("trace", msg)
+}
+
+func (w *testWriter) Debug(msg string) {
+	w.log("debug", msg)
+}
+
+func (w *testWriter) Info(msg string) {
+	w.log("info", msg)
+}
+
+func (w *testWriter) Warn(msg string) {
+	w.log("warn", msg)
+}
+
+func (w *testWriter) Log(level dazl.Level, msg string) {
+	w.log(level.String(), msg)
+}
+
+func (w *testWriter) Error(msg string) {
+	w.log("error", msg)
+}
+
+func (w *testWriter) Fatal(msg string) {
+	w.log("fatal", msg)
+}
+
+func (w *testWriter) Panic(msg string) {
+	w.log("panic", msg)
+}
+
+func (w *testWriter) log(level string, msg string) {
+	// Skip log, the Writer method and the testLogger method
+	_, file, line, _ := runtime.Caller(3 + w.skipCalls)
+	elems := []string{level, fmt.Sprintf("%s:%d", filepath.Base(file), line), msg}
+	elems = append(elems, w.fields...)
+	*w.entries = append(*w.entries, strings.Join(elems, " "))
+}
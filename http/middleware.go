@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"github.com/atomix/dazl"
+	"io"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	methodKey     = "http.method"
+	pathKey       = "http.path"
+	statusKey     = "http.status"
+	bytesKey      = "http.bytes"
+	latencyKey    = "http.latency"
+	remoteAddrKey = "http.remote_addr"
+)
+
+// Option is a middleware option
+type Option func(*options)
+
+type options struct {
+	levels [6]dazl.Level
+}
+
+// WithStatusClassLevel sets the level at which requests with the given status class are logged,
+// e.g. WithStatusClassLevel(4, dazl.InfoLevel) logs all 4xx responses at the info level. Any level
+// can be used, including the trace level and custom levels registered with dazl.RegisterLevel.
+func WithStatusClassLevel(class int, level dazl.Level) Option {
+	return func(options *options) {
+		if class > 0 && class < len(options.levels) {
+			options.levels[class] = level
+		}
+	}
+}
+
+// Middleware returns a function that wraps an http.Handler with NewHandler
+func Middleware(logger dazl.Logger, opts ...Option) func(http.Handler) http.Handler {
+	return func(handler http.Handler) http.Handler {
+		return NewHandler(logger, handler, opts...)
+	}
+}
+
+// NewHandler returns an http.Handler that logs each request served by the given handler to the given Logger.
+// Requests are logged at the info level for 1xx, 2xx and 3xx responses, the warn level for 4xx responses and
+// the error level for 5xx responses unless overridden with WithStatusClassLevel. Fields carried by the request
+// context are added to each entry. A child logger with the request method and path fields is added to the
+// request context and can be retrieved with FromContext.
+func NewHandler(logger dazl.Logger, handler http.Handler, opts ...Option) http.Handler {
+	options := options{
+		levels: [6]dazl.Level{
+			dazl.InfoLevel,
+			dazl.InfoLevel,
+			dazl.InfoLevel,
+			dazl.InfoLevel,
+			dazl.WarnLevel,
+			dazl.ErrorLevel,
+		},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+	return &loggingHandler{
+		logger:  logger,
+		handler: handler,
+		options: options,
+	}
+}
+
+type loggingHandler struct {
+	logger  dazl.Logger
+	handler http.Handler
+	options options
+}
+
+func (h *loggingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	logger := h.logger.WithContext(r.Context()).WithFields(
+		dazl.String(methodKey, r.Method),
+		dazl.String(pathKey, r.URL.Path))
+	writer := &responseWriter{
+		ResponseWriter: w,
+		status:         http.StatusOK,
+	}
+	start := time.Now()
+	h.handler.ServeHTTP(writer, r.WithContext(WithLogger(r.Context(), logger)))
+	latency := time.Since(start)
+
+	fields := []dazl.Field{
+		dazl.Int(statusKey, writer.status),
+		dazl.Int(bytesKey, writer.bytes),
+		dazl.Duration(latencyKey, latency),
+		dazl.String(remoteAddrKey, r.RemoteAddr),
+	}
+	logger.Log(h.levelFor(writer.status), "Finished request", fields...)
+}
+
+// levelFor returns the level at which requests with the given status code are logged
+func (h *loggingHandler) levelFor(status int) dazl.Level {
+	class := status / 100
+	if class < 0 || class >= len(h.options.levels) {
+		return dazl.ErrorLevel
+	}
+	return h.options.levels[class]
+}
+
+// responseWriter is an http.ResponseWriter that records the response status and size
+type responseWriter struct {
+	http.ResponseWriter
+	status      int
+	bytes       int
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(status int) {
+	if !w.wroteHeader {
+		w.status = status
+		w.wroteHeader = true
+	}
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *responseWriter) Write(p []byte) (int, error) {
+	w.wroteHeader = true
+	n, err := w.ResponseWriter.Write(p)
+	w.bytes += n
+	return n, err
+}
+
+func (w *responseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		flusher.Flush()
+	}
+}
+
+// Hijack hijacks the connection of the underlying http.ResponseWriter, e.g. to upgrade it to a websocket.
+// Responses to hijacked connections are written to the connection, so they're logged as switching protocols.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("http.Hijacker is not supported by the response writer")
+	}
+	conn, rw, err := hijacker.Hijack()
+	if err == nil && !w.wroteHeader {
+		w.status = http.StatusSwitchingProtocols
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// ReadFrom copies the reader to the underlying http.ResponseWriter, using its io.ReaderFrom if supported
+func (w *responseWriter) ReadFrom(r io.Reader) (int64, error) {
+	w.wroteHeader = true
+	var n int64
+	var err error
+	if readerFrom, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		n, err = readerFrom.ReadFrom(r)
+	} else {
+		n, err = io.Copy(w.ResponseWriter, r)
+	}
+	w.bytes += int(n)
+	return n, err
+}
+
+// Unwrap returns the underlying http.ResponseWriter
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+type loggerKey struct{}
+
+// WithLogger returns a copy of the given context carrying the given Logger
+func WithLogger(ctx context.Context, logger dazl.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the request-scoped Logger carried by the given context,
+// or the root logger if the context does not carry a Logger
+func FromContext(ctx context.Context) dazl.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(dazl.Logger); ok {
+		return logger
+	}
+	return dazl.GetRootLogger()
+}
@@ -0,0 +1,233 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package http
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/atomix/dazl"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMiddleware(t *testing.T) {
+	entries := &[]string{}
+	logger := &testLogger{
+		writer: &testWriter{entries: entries},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/ok", func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Info("Hello world!")
+		_, _ = w.Write([]byte("Hello world!"))
+	})
+	mux.HandleFunc("/missing", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "not found", http.StatusNotFound)
+	})
+	mux.HandleFunc("/error", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("/moved", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/ok", http.StatusMovedPermanently)
+	})
+	handler := Middleware(logger, WithStatusClassLevel(3, dazl.TraceLevel), WithStatusClassLevel(4, dazl.DebugLevel))(mux)
+
+	request := httptest.NewRequest(http.MethodGet, "/ok?foo=bar", nil)
+	request.RemoteAddr = "10.0.0.1:1234"
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Len(t, *entries, 2)
+	assert.Equal(t, "info Hello world! http.method=GET http.path=/ok", (*entries)[0])
+	assert.Regexp(t, `^info Finished request http.method=GET http.path=/ok http.status=200 http.bytes=12 http.latency=\S+ http.remote_addr=10.0.0.1:1234$`, (*entries)[1])
+
+	request = httptest.NewRequest(http.MethodPost, "/missing", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Len(t, *entries, 3)
+	assert.Regexp(t, `^debug Finished request http.method=POST http.path=/missing http.status=404 http.bytes=10 `, (*entries)[2])
+
+	request = httptest.NewRequest(http.MethodDelete, "/error", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Len(t, *entries, 4)
+	assert.Regexp(t, `^error Finished request http.method=DELETE http.path=/error http.status=500 http.bytes=0 `, (*entries)[3])
+
+	request = httptest.NewRequest(http.MethodGet, "/ok", nil)
+	request = request.WithContext(dazl.ContextWithFields(request.Context(), dazl.String("request-id", "1234")))
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Len(t, *entries, 6)
+	assert.Equal(t, "info Hello world! request-id=1234 http.method=GET http.path=/ok", (*entries)[4])
+	assert.Regexp(t, `^info Finished request request-id=1234 http.method=GET http.path=/ok http.status=200 `, (*entries)[5])
+
+	request = httptest.NewRequest(http.MethodGet, "/moved", nil)
+	handler.ServeHTTP(httptest.NewRecorder(), request)
+	assert.Len(t, *entries, 7)
+	assert.Regexp(t, `^trace Finished request http.method=GET http.path=/moved http.status=301 `, (*entries)[6])
+}
+
+func TestResponseWriter(t *testing.T) {
+	entries := &[]string{}
+	logger := &testLogger{
+		writer: &testWriter{entries: entries},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/copy", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(w, strings.NewReader("Hello world!"))
+	})
+	mux.HandleFunc("/upgrade", func(w http.ResponseWriter, r *http.Request) {
+		conn, rw, err := w.(http.Hijacker).Hijack()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		_, _ = rw.WriteString("HTTP/1.1 101 Switching Protocols\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n")
+		_ = rw.Flush()
+	})
+	handler := Middleware(logger)(mux)
+
+	request := httptest.NewRequest(http.MethodGet, "/copy", nil)
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	assert.Equal(t, "Hello world!", recorder.Body.String())
+	assert.Len(t, *entries, 1)
+	assert.Regexp(t, `^info Finished request http.method=GET http.path=/copy http.status=200 http.bytes=12 `, (*entries)[0])
+
+	// Hijacking requires a real connection, so the handler is served and signals when the request is logged
+	done := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		defer close(done)
+		handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	conn, err := net.Dial("tcp", server.Listener.Addr().String())
+	assert.NoError(t, err)
+	defer conn.Close()
+	_, err = conn.Write([]byte("GET /upgrade HTTP/1.1\r\nHost: test\r\nUpgrade: test\r\nConnection: Upgrade\r\n\r\n"))
+	assert.NoError(t, err)
+	status, err := bufio.NewReader(conn).ReadString('\n')
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP/1.1 101 Switching Protocols\r\n", status)
+
+	<-done
+	assert.Len(t, *entries, 2)
+	assert.Regexp(t, `^info Finished request http.method=GET http.path=/upgrade http.status=101 http.bytes=0 `, (*entries)[1])
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Equal(t, dazl.GetRootLogger(), FromContext(context.Background()))
+	logger := &testLogger{}
+	assert.Equal(t, logger, FromContext(WithLogger(context.Background(), logger)))
+}
+
+// testLogger is a minimal dazl.Logger that writes to a single writer
+type testLogger struct {
+	dazl.Logger
+	writer dazl.Writer
+}
+
+func (l *testLogger) WithFields(fields ...dazl.Field) dazl.Logger {
+	writer := l.writer
+	for _, field := range fields {
+		var err error
+		if writer, err = field(writer); err != nil {
+			panic(err)
+		}
+	}
+	return &testLogger{
+		writer: writer,
+	}
+}
+
+func (l *testLogger) WithContext(ctx context.Context) dazl.Logger {
+	return l.WithFields(dazl.FieldsFromContext(ctx)...)
+}
+
+func (l *testLogger) Info(args ...any) {
+	l.writer.Info(fmt.Sprint(args...))
+}
+
+func (l *testLogger) Log(level dazl.Level, msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).(*testLogger).writer.Log(level, msg)
+}
+
+// testWriter is a dazl.Writer that records entries as strings
+type testWriter struct {
+	entries *[]string
+	fields  []string
+}
+
+func (w *testWriter) WithName(name string) dazl.Writer {
+	return w
+}
+
+func (w *testWriter) WithSkipCalls(calls int) dazl.Writer {
+	return w
+}
+
+func (w *testWriter) withField(name string, value any) dazl.Writer {
+	fields := make([]string, 0, len(w.fields)+1)
+	fields = append(fields, w.fields...)
+	fields = append(fields, fmt.Sprintf("%s=%v", name, value))
+	return &testWriter{
+		entries: w.entries,
+		fields:  fields,
+	}
+}
+
+func (w *testWriter) WithStringField(name string, value string) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) WithIntField(name string, value int) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) WithDurationField(name string, value time.Duration) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) Trace(msg string) {
+	w.log("trace", msg)
+}
+
+func (w *testWriter) Debug(msg string) {
+	w.log("debug", msg)
+}
+
+func (w *testWriter) Info(msg string) {
+	w.log("info", msg)
+}
+
+func (w *testWriter) Warn(msg string) {
+	w.log("warn", msg)
+}
+
+func (w *testWriter) Log(level dazl.Level, msg string) {
+	w.log(level.String(), msg)
+}
+
+func (w *testWriter) Error(msg string) {
+	w.log("error", msg)
+}
+
+func (w *testWriter) Fatal(msg string) {
+	w.log("fatal", msg)
+}
+
+func (w *testWriter) Panic(msg string) {
+	w.log("panic", msg)
+}
+
+func (w *testWriter) log(level string, msg string) {
+	elems := append([]string{level, msg}, w.fields...)
+	*w.entries = append(*w.entries, strings.Join(elems, " "))
+}
@@ -4,6 +4,13 @@
 
 package dazl
 
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
 // Level :
 type Level int32
 
@@ -24,14 +31,231 @@ const (
 	FatalLevel
 )
 
+// TraceLevel logs a message at trace level, below debug level
+const TraceLevel Level = -1
+
 // Enabled indicates whether the log level is enabled
 func (l Level) Enabled(level Level) bool {
-	return l <= level
+	// Loggers without a level write entries at the debug level and above, so trace is enabled explicitly
+	if l == EmptyLevel {
+		l = DebugLevel
+	}
+	return l.severity() <= level.severity()
+}
+
+// severity returns the severity of the level. The built-in levels are ten apart on the severity scale, from
+// trace at 10 to fatal at 70, and custom levels are registered with their severity on the same scale.
+func (l Level) severity() Level {
+	switch l {
+	case EmptyLevel:
+		return 0
+	case TraceLevel:
+		return 10
+	case DebugLevel:
+		return 20
+	case InfoLevel:
+		return 30
+	case WarnLevel:
+		return 40
+	case ErrorLevel:
+		return 50
+	case PanicLevel:
+		return 60
+	case FatalLevel:
+		return 70
+	}
+	return l
 }
 
 // String :
 func (l Level) String() string {
-	return [...]string{"", "debug", "info", "warn", "error", "panic", "fatal"}[l]
+	switch l {
+	case EmptyLevel:
+		return ""
+	case TraceLevel:
+		return "trace"
+	case DebugLevel:
+		return "debug"
+	case InfoLevel:
+		return "info"
+	case WarnLevel:
+		return "warn"
+	case ErrorLevel:
+		return "error"
+	case PanicLevel:
+		return "panic"
+	case FatalLevel:
+		return "fatal"
+	}
+	if custom, ok := getLevels().byLevel[l]; ok {
+		return custom.name
+	}
+	return strconv.Itoa(int(l))
+}
+
+// builtinSeverity returns the built-in level with the given severity, if any
+func builtinSeverity(severity Level) (Level, bool) {
+	for level := TraceLevel; level <= FatalLevel; level++ {
+		if level != EmptyLevel && level.severity() == severity {
+			return level, true
+		}
+	}
+	return EmptyLevel, false
+}
+
+// unfiltered returns whether the level is a custom level registered with the Unfiltered option
+func (l Level) unfiltered() bool {
+	custom, ok := getLevels().byLevel[l]
+	return ok && custom.unfiltered
+}
+
+// LevelOption is an option for a custom level
+type LevelOption func(*customLevel)
+
+// Unfiltered returns an option that writes entries at the custom level to all of a logger's outputs
+// regardless of the logger and output levels and sampling
+func Unfiltered() LevelOption {
+	return func(level *customLevel) {
+		level.unfiltered = true
+	}
+}
+
+// customLevel is a level registered with RegisterLevel
+type customLevel struct {
+	name       string
+	level      Level
+	unfiltered bool
+}
+
+// levelRegistry is an immutable snapshot of the registered custom levels
+type levelRegistry struct {
+	byName  map[string]*customLevel
+	byLevel map[Level]*customLevel
+}
+
+var (
+	customLevels   atomic.Value
+	customLevelsMu sync.Mutex
+)
+
+func getLevels() *levelRegistry {
+	registry, _ := customLevels.Load().(*levelRegistry)
+	if registry == nil {
+		return &levelRegistry{}
+	}
+	return registry
+}
+
+// RegisterLevel registers a custom level with the given name and severity. Custom levels can be configured by
+// name like the built-in levels and are written with Logger.Log. The severity orders the level relative to the
+// built-in levels, which are ten apart from trace at 10 to fatal at 70, e.g. a level with severity 45 is enabled
+// for loggers at the warn level. The severity of a custom level is also its Level value, so the level is written
+// with Logger.Log(severity, ...). Names must be lower case letters, digits, '-' or '_'. Registering a level again
+// with the same name and severity replaces its options; an error is returned if the name or severity is invalid
+// or already in use by another level.
+func RegisterLevel(name string, severity Level, opts ...LevelOption) error {
+	if err := validateLevelName(name); err != nil {
+		return err
+	}
+	if err := validateSeverity(severity); err != nil {
+		return fmt.Errorf("invalid severity %d for level '%s': %s", severity, name, err)
+	}
+	level := severity
+	if _, ok := parseBuiltinLevel(name); ok {
+		return fmt.Errorf("level '%s' is a built-in level", name)
+	}
+
+	custom := &customLevel{
+		name:  name,
+		level: level,
+	}
+	for _, opt := range opts {
+		opt(custom)
+	}
+
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+	registry := getLevels()
+	if existing, ok := registry.byName[name]; ok && existing.level != level {
+		return fmt.Errorf("level '%s' is already registered with severity %d", name, existing.level)
+	}
+	if existing, ok := registry.byLevel[level]; ok && existing.name != name {
+		return fmt.Errorf("severity %d is already registered for level '%s'", level, existing.name)
+	}
+
+	// The registry is copied on write so levels can be looked up without locking
+	updated := &levelRegistry{
+		byName:  make(map[string]*customLevel, len(registry.byName)+1),
+		byLevel: make(map[Level]*customLevel, len(registry.byLevel)+1),
+	}
+	for _, existing := range registry.byName {
+		updated.byName[existing.name] = existing
+		updated.byLevel[existing.level] = existing
+	}
+	updated.byName[name] = custom
+	updated.byLevel[level] = custom
+	customLevels.Store(updated)
+	return nil
+}
+
+// validateSeverity checks that the given severity can be used by a custom level
+func validateSeverity(severity Level) error {
+	if severity <= TraceLevel.severity() {
+		return fmt.Errorf("severity must be above the trace level (%d)", TraceLevel.severity())
+	}
+	if level, ok := builtinSeverity(severity); ok {
+		return fmt.Errorf("severity is used by level '%s'", level)
+	}
+	return nil
+}
+
+// validateLevelName checks that the given name can be used as a level name
+func validateLevelName(name string) error {
+	if name == "" {
+		return fmt.Errorf("level name must not be empty")
+	}
+	for _, c := range name {
+		if (c < 'a' || c > 'z') && (c < '0' || c > '9') && c != '-' && c != '_' {
+			return fmt.Errorf("invalid level name '%s': names must be lower case letters, digits, '-' or '_'", name)
+		}
+	}
+	return nil
+}
+
+// levelsKey is the configuration key of the custom levels
+const levelsKey = "levels"
+
+// levelsConfig is the configuration of custom levels, keyed by level name
+type levelsConfig map[string]customLevelConfig
+
+// register registers the configured custom levels
+func (c levelsConfig) register() error {
+	for name, config := range c {
+		var opts []LevelOption
+		if config.Unfiltered {
+			opts = append(opts, Unfiltered())
+		}
+		if err := RegisterLevel(name, config.Severity, opts...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// customLevelConfig is the configuration of a custom level, either a severity or a mapping of options
+type customLevelConfig struct {
+	Severity   Level `json:"severity" yaml:"severity"`
+	Unfiltered bool  `json:"unfiltered" yaml:"unfiltered"`
+}
+
+func (c *customLevelConfig) UnmarshalYAML(unmarshal func(any) error) error {
+	var severity int32
+	if err := unmarshal(&severity); err == nil {
+		c.Severity = Level(severity)
+		return nil
+	}
+	type schema customLevelConfig
+	return unmarshal((*schema)(c))
 }
 
 type levelConfig Level
@@ -41,21 +265,40 @@ func (c levelConfig) Level() Level {
 }
 
 func (c *levelConfig) UnmarshalText(text []byte) error {
-	switch string(text) {
+	level, _ := parseLevel(string(text))
+	*c = levelConfig(level)
+	return nil
+}
+
+// parseLevel returns the built-in or registered custom Level with the given name
+func parseLevel(name string) (Level, bool) {
+	if level, ok := parseBuiltinLevel(name); ok {
+		return level, true
+	}
+	if custom, ok := getLevels().byName[name]; ok {
+		return custom.level, true
+	}
+	return EmptyLevel, false
+}
+
+// parseBuiltinLevel returns the built-in Level with the given name
+func parseBuiltinLevel(name string) (Level, bool) {
+	switch name {
+	case TraceLevel.String():
+		return TraceLevel, true
 	case DebugLevel.String():
-		*c = levelConfig(DebugLevel)
+		return DebugLevel, true
 	case InfoLevel.String():
-		*c = levelConfig(InfoLevel)
+		return InfoLevel, true
 	case WarnLevel.String():
-		*c = levelConfig(WarnLevel)
+		return WarnLevel, true
 	case ErrorLevel.String():
-		*c = levelConfig(ErrorLevel)
+		return ErrorLevel, true
 	case PanicLevel.String():
-		*c = levelConfig(PanicLevel)
+		return PanicLevel, true
 	case FatalLevel.String():
-		*c = levelConfig(FatalLevel)
+		return FatalLevel, true
 	default:
-		*c = levelConfig(EmptyLevel)
+		return EmptyLevel, false
 	}
-	return nil
 }
@@ -5,12 +5,21 @@
 package dazl
 
 import (
+	"errors"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
+	"path/filepath"
 	"testing"
 )
 
 func TestLevel(t *testing.T) {
+	assert.Equal(t, []Level{0, 1, 2, 3, 4, 5, 6},
+		[]Level{EmptyLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel, PanicLevel, FatalLevel})
+	assert.False(t, TraceLevel.Enabled(EmptyLevel))
+	assert.True(t, TraceLevel.Enabled(TraceLevel))
+	assert.False(t, EmptyLevel.Enabled(TraceLevel))
+	assert.True(t, EmptyLevel.Enabled(DebugLevel))
+	assert.False(t, DebugLevel.Enabled(TraceLevel))
 	assert.False(t, DebugLevel.Enabled(EmptyLevel))
 	assert.True(t, DebugLevel.Enabled(DebugLevel))
 	assert.False(t, InfoLevel.Enabled(DebugLevel))
@@ -32,4 +41,92 @@ func TestUnmarshalLevel(t *testing.T) {
 	assert.NotEqual(t, InfoLevel, level.Level())
 	assert.NoError(t, yaml.Unmarshal([]byte(testLevel), &level))
 	assert.Equal(t, InfoLevel, level.Level())
+	assert.NoError(t, yaml.Unmarshal([]byte("trace"), &level))
+	assert.Equal(t, TraceLevel, level.Level())
+	assert.Equal(t, "trace", TraceLevel.String())
+}
+
+func TestRegisterLevel(t *testing.T) {
+	assert.NoError(t, RegisterLevel("notice", 35))
+	assert.NoError(t, RegisterLevel("notice", 35))
+	assert.Equal(t, "notice", Level(35).String())
+
+	level, ok := parseLevel("notice")
+	assert.True(t, ok)
+	assert.Equal(t, Level(35), level)
+	assert.True(t, InfoLevel.Enabled(level))
+	assert.False(t, WarnLevel.Enabled(level))
+	assert.False(t, level.unfiltered())
+
+	assert.NoError(t, RegisterLevel("notice", 35, Unfiltered()))
+	assert.True(t, level.unfiltered())
+	assert.NoError(t, RegisterLevel("notice", 35))
+	assert.False(t, level.unfiltered())
+
+	assert.EqualError(t, RegisterLevel("notice", 36), "level 'notice' is already registered with severity 35")
+	assert.EqualError(t, RegisterLevel("alert", 35), "severity 35 is already registered for level 'notice'")
+	assert.EqualError(t, RegisterLevel("Alert", 36), "invalid level name 'Alert': names must be lower case letters, digits, '-' or '_'")
+	assert.EqualError(t, RegisterLevel("alert", 10), "invalid severity 10 for level 'alert': severity must be above the trace level (10)")
+	assert.EqualError(t, RegisterLevel("alert", 50), "invalid severity 50 for level 'alert': severity is used by level 'error'")
+	assert.EqualError(t, RegisterLevel("warn", 36), "level 'warn' is a built-in level")
+	assert.Equal(t, "36", Level(36).String())
+}
+
+const testCustomLevelsConfig = `levels:
+  notice: 35
+  audit:
+    severity: 45
+    unfiltered: true
+rootLogger:
+  level: notice
+  outputs:
+    stdout:
+      level: audit
+writers:
+  stdout:
+    encoder: console
+`
+
+func TestCustomLevelsConfig(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, configFile)
+	writeFile(t, path, testCustomLevelsConfig)
+
+	var config loggingConfig
+	assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &config))
+	assert.Equal(t, Level(35), config.RootLogger.Level.Level())
+	assert.Equal(t, Level(45), config.RootLogger.Outputs.Outputs["stdout"].Level.Level())
+	assert.Equal(t, "audit", Level(45).String())
+	assert.True(t, Level(45).unfiltered())
+
+	writeFile(t, path, `levels:
+  notice: 36
+  Alert: 46
+  critical: 50
+  emergency:
+    unfiltered: maybe
+  verbose: 15
+  chatty: 15
+  audit: loud
+  quiet: 5
+rootLogger:
+  level: verbose
+loggers:
+  foo:
+    level: chatty
+`)
+	err := loadFiles([]string{path}, &defaultFramework{}, &loggingConfig{})
+	var configErrors ConfigErrors
+	assert.True(t, errors.As(err, &configErrors))
+	assert.Equal(t, []string{
+		path + ":2:11: level 'notice' is already registered with severity 35",
+		path + ":3:3: invalid level name 'Alert': names must be lower case letters, digits, '-' or '_'",
+		path + ":4:13: severity 50 of level 'critical' is used by level 'error'",
+		path + ":5:3: level 'emergency' must configure a severity",
+		path + ":6:17: unfiltered must be true or false",
+		path + ":8:11: severity 15 of level 'chatty' is already configured for level 'verbose'",
+		path + ":9:10: severity of level 'audit' must be an integer",
+		path + ":10:10: severity 5 of level 'quiet' must be above the trace level (10)",
+		path + ":15:12: unknown level 'chatty'",
+	}, errorStrings(configErrors))
 }
@@ -0,0 +1,425 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"fmt"
+	"github.com/mitchellh/go-homedir"
+	"gopkg.in/yaml.v3"
+	"io/fs"
+	"os"
+	pathpkg "path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"sync/atomic"
+)
+
+const configFile = "logging.yaml"
+const configEnv = "LOGGING_CONFIG"
+const configDataEnv = "LOGGING_CONFIG_DATA"
+const includeKey = "include"
+
+// configFiles are the names of the configuration files searched for in each location, in order of preference
+var configFiles = []string{configFile, "logging.yml", "logging.json", "logging.toml"}
+
+// autoConfigureEnv is the environment variable that disables loading the configuration files when set to false
+const autoConfigureEnv = "DAZL_AUTOCONFIGURE"
+
+var autoConfigureDisabled atomic.Bool
+
+// autoConfigured records whether a framework loaded the configuration when it was registered
+var autoConfigured atomic.Bool
+
+// DisableAutoConfigure disables loading the configuration files and LOGGING_CONFIG_DATA, like setting
+// DAZL_AUTOCONFIGURE=false. Frameworks load the configuration when they're registered during package
+// initialization, so to apply to the frameworks it must be called from the init function of a package
+// that's initialized before the framework packages.
+func DisableAutoConfigure() {
+	autoConfigureDisabled.Store(true)
+}
+
+// AutoConfigured returns whether a framework loaded the configuration files and LOGGING_CONFIG_DATA when it
+// was registered, e.g. to check that DisableAutoConfigure was called before the framework packages were initialized.
+func AutoConfigured() bool {
+	return autoConfigured.Load()
+}
+
+// autoConfigureEnabled returns whether the configuration files are loaded
+func autoConfigureEnabled() bool {
+	if autoConfigureDisabled.Load() {
+		return false
+	}
+	autoConfigure, err := strconv.ParseBool(os.Getenv(autoConfigureEnv))
+	return err != nil || autoConfigure
+}
+
+// load the dazl configuration, validating it for the given framework
+func load(framework Framework, config *loggingConfig) error {
+	inputs, err := configInputs()
+	if err != nil {
+		return err
+	}
+	return loadInputs(inputs, framework, config)
+}
+
+// loadFile loads the dazl configuration from the given path
+func loadFile(path string, config *loggingConfig) error {
+	return loadFiles([]string{path}, nil, config)
+}
+
+// loadFiles loads the dazl configuration by merging the files at the given paths in order,
+// validating the merged configuration for the given framework if not nil
+func loadFiles(paths []string, framework Framework, config *loggingConfig) error {
+	return loadInputs(fileInputs(paths), framework, config)
+}
+
+// loadInputs loads the dazl configuration by merging the given inputs in order,
+// validating the merged configuration for the given framework if not nil
+func loadInputs(inputs []configInput, framework Framework, config *loggingConfig) error {
+	source, err := readInputs(inputs)
+	if err != nil {
+		return err
+	}
+	if err := validate(source, framework); err != nil {
+		return err
+	}
+	return source.decode(config)
+}
+
+// LoadFS loads the logging configuration file at the given path in the given filesystem, e.g. a configuration
+// embedded in the binary with an embed.FS, and applies it to all existing loggers. The file is the base layer of
+// the configuration: the configuration files and the LOGGING_CONFIG_DATA environment variable override it, and
+// it's kept when the configuration is reloaded. Files included by the file are read from the same filesystem.
+func LoadFS(fsys fs.FS, path string) error {
+	baseConfigMu.Lock()
+	defer baseConfigMu.Unlock()
+	base := &configInput{fsys: fsys, path: pathpkg.Clean(path)}
+	inputs, err := layerConfigInputs(base)
+	if err != nil {
+		return err
+	}
+	source, err := readInputs(inputs)
+	if err != nil {
+		return err
+	}
+	if err := reload(source, open); err != nil {
+		return err
+	}
+	baseConfig = base
+	return nil
+}
+
+var (
+	baseConfig   *configInput
+	baseConfigMu sync.RWMutex
+)
+
+// configInput is a configuration file to read, or configuration content read from the environment
+type configInput struct {
+	// fsys is the filesystem containing the file, or nil if the file is in the OS filesystem
+	fsys fs.FS
+	// path is the path of the file, or the name of the environment variable containing the configuration
+	path string
+	// data is the configuration content read from the environment, or nil if the input is a file
+	data []byte
+}
+
+// fileInputs returns inputs for the files at the given paths in the OS filesystem
+func fileInputs(paths []string) []configInput {
+	inputs := make([]configInput, 0, len(paths))
+	for _, path := range paths {
+		inputs = append(inputs, configInput{path: path})
+	}
+	return inputs
+}
+
+// configInputs returns the configuration inputs in order of increasing precedence
+func configInputs() ([]configInput, error) {
+	baseConfigMu.RLock()
+	defer baseConfigMu.RUnlock()
+	return layerConfigInputs(baseConfig)
+}
+
+// layerConfigInputs returns the configuration inputs in order of increasing precedence: the given base
+// configuration loaded with LoadFS, the configuration files, and the configuration content in LOGGING_CONFIG_DATA.
+// Only the base configuration is returned if automatic configuration is disabled.
+func layerConfigInputs(base *configInput) ([]configInput, error) {
+	var inputs []configInput
+	if base != nil {
+		inputs = append(inputs, *base)
+	}
+	if !autoConfigureEnabled() {
+		return inputs, nil
+	}
+	paths, err := configPaths()
+	if err != nil {
+		return nil, err
+	}
+	inputs = append(inputs, fileInputs(paths)...)
+	if data := os.Getenv(configDataEnv); data != "" {
+		inputs = append(inputs, configInput{path: configDataEnv, data: []byte(data)})
+	}
+	return inputs, nil
+}
+
+// configPaths returns the paths of the existing configuration files in order of increasing precedence:
+// the system configuration, the user configuration, the project configuration and the configuration
+// named by the LOGGING_CONFIG environment variable
+func configPaths() ([]string, error) {
+	dirs := []string{"/etc/dazl"}
+	if home, err := homedir.Dir(); err == nil {
+		dirs = append(dirs, home)
+	}
+	dirs = append(dirs, "")
+
+	var candidates []string
+	for _, dir := range dirs {
+		path, err := findConfigFile(dir)
+		if err != nil {
+			return nil, err
+		}
+		if path != "" {
+			candidates = append(candidates, path)
+		}
+	}
+	if configPath := os.Getenv(configEnv); configPath != "" {
+		candidates = append(candidates, configPath)
+	}
+
+	var paths []string
+	found := make(map[string]bool)
+	for _, path := range candidates {
+		if _, err := os.Stat(path); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		// The project and user configurations are the same file when running from the home directory
+		if abs, err := filepath.Abs(path); err == nil {
+			if found[abs] {
+				continue
+			}
+			found[abs] = true
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// findConfigFile returns the path of the first configuration file found in the given directory,
+// or an empty string if the directory contains no configuration file
+func findConfigFile(dir string) (string, error) {
+	for _, name := range configFiles {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+	}
+	return "", nil
+}
+
+// configSource is a configuration merged from a set of files
+type configSource struct {
+	// node is the merged configuration, or nil if no configuration was read
+	node *yaml.Node
+	// files is the contents of each file read, including included files
+	files map[string][]byte
+	// nodes is the path of the file from which each node was read
+	nodes map[*yaml.Node]string
+}
+
+// readConfig reads and merges the configuration files at the given paths in order
+func readConfig(paths []string) (*configSource, error) {
+	return readInputs(fileInputs(paths))
+}
+
+// readInputs reads and merges the given configuration inputs in order, applying the active profile
+func readInputs(inputs []configInput) (*configSource, error) {
+	source := &configSource{
+		files: make(map[string][]byte),
+		nodes: make(map[*yaml.Node]string),
+	}
+	for _, input := range inputs {
+		node, err := source.read(input, nil)
+		if err != nil {
+			return nil, err
+		}
+		source.node = mergeNodes(source.node, node)
+	}
+	if err := source.applyProfile(activeProfile()); err != nil {
+		return nil, err
+	}
+	return source, nil
+}
+
+// read reads the given configuration input, merging the input over the files it includes
+func (s *configSource) read(input configInput, includedBy []string) (*yaml.Node, error) {
+	path := input.path
+	for _, parent := range includedBy {
+		if parent == path {
+			return nil, fmt.Errorf("%s: circular include", path)
+		}
+	}
+
+	format, err := input.format()
+	if err != nil {
+		return nil, err
+	}
+	data := input.data
+	if data == nil {
+		if input.fsys != nil {
+			data, err = fs.ReadFile(input.fsys, path)
+		} else {
+			data, err = os.ReadFile(path)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	s.files[path] = data
+
+	node, err := parseConfig(path, format, data)
+	if err != nil || node == nil {
+		return nil, err
+	}
+	s.track(path, node)
+	if node.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("%s: configuration must be a mapping", position(path, node))
+	}
+
+	interpolate(node)
+	includes, err := removeIncludes(path, node)
+	if err != nil {
+		return nil, err
+	}
+	var merged *yaml.Node
+	for _, include := range includes {
+		included, err := s.read(input.include(include), append(includedBy, path))
+		if err != nil {
+			return nil, err
+		}
+		merged = mergeNodes(merged, included)
+	}
+	return mergeNodes(merged, node), nil
+}
+
+// include returns the input for a file included by the input. Relative paths are resolved relative to the
+// including file in the same filesystem, or relative to the working directory for content read from the environment.
+func (i configInput) include(include string) configInput {
+	if i.fsys != nil {
+		if !pathpkg.IsAbs(include) {
+			include = pathpkg.Join(pathpkg.Dir(i.path), include)
+		}
+		return configInput{fsys: i.fsys, path: include}
+	}
+	if !filepath.IsAbs(include) && i.data == nil {
+		include = filepath.Join(filepath.Dir(i.path), include)
+	}
+	return configInput{path: include}
+}
+
+// track records the path of the file from which the given node and its descendants were read
+func (s *configSource) track(path string, node *yaml.Node) {
+	s.nodes[node] = path
+	for _, child := range node.Content {
+		s.track(path, child)
+	}
+}
+
+// decode decodes the merged configuration
+func (s *configSource) decode(config *loggingConfig) error {
+	if s.node == nil {
+		return nil
+	}
+	// Custom levels are registered first so levels configured by name can be resolved
+	if node := lookup(s.node, levelsKey); node != nil {
+		var levels levelsConfig
+		if err := node.Decode(&levels); err != nil {
+			return err
+		}
+		if err := levels.register(); err != nil {
+			return err
+		}
+	}
+	return s.node.Decode(config)
+}
+
+// removeIncludes removes the include directive from the given mapping node, returning the included paths
+func removeIncludes(path string, node *yaml.Node) ([]string, error) {
+	for i := 0; i < len(node.Content); i += 2 {
+		if node.Content[i].Value != includeKey {
+			continue
+		}
+		value := node.Content[i+1]
+		node.Content = append(node.Content[:i], node.Content[i+2:]...)
+		switch value.Kind {
+		case yaml.ScalarNode:
+			return []string{value.Value}, nil
+		case yaml.SequenceNode:
+			var includes []string
+			if err := value.Decode(&includes); err != nil {
+				return nil, fmt.Errorf("%s: %w", position(path, value), err)
+			}
+			return includes, nil
+		default:
+			return nil, fmt.Errorf("%s: include must be a path or a list of paths", position(path, value))
+		}
+	}
+	return nil, nil
+}
+
+// mergeNodes deep merges the src node over the dst node. Mappings are merged recursively, empty values
+// in src leave mappings in dst unchanged, and all other values in src replace the corresponding values in dst.
+func mergeNodes(dst, src *yaml.Node) *yaml.Node {
+	if dst == nil {
+		return src
+	}
+	if src == nil {
+		return dst
+	}
+	if dst.Kind == yaml.MappingNode && src.Kind == yaml.ScalarNode && src.Tag == "!!null" {
+		return dst
+	}
+	if dst.Kind != yaml.MappingNode || src.Kind != yaml.MappingNode {
+		return src
+	}
+	for i := 0; i < len(src.Content); i += 2 {
+		key, value := src.Content[i], src.Content[i+1]
+		merged := false
+		for j := 0; j < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				dst.Content[j+1] = mergeNodes(dst.Content[j+1], value)
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			dst.Content = append(dst.Content, key, value)
+		}
+	}
+	return dst
+}
+
+// position returns the file path and the position of the given node in the file, if known
+func position(path string, node *yaml.Node) string {
+	if node.Line == 0 {
+		return path
+	}
+	return fmt.Sprintf("%s:%d:%d", path, node.Line, node.Column)
+}
+
+// lookup returns the value of the given key in the mapping node, or nil if the key is not set
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+)
+
+const testSystemConfig = `
+encoders:
+  json:
+    fields:
+      - message
+      - level
+writers:
+  stdout:
+    encoder: json
+  file:
+    path: /var/log/app.log
+    encoder: json
+rootLogger:
+  level: warn
+  outputs:
+    - stdout
+loggers:
+  foo:
+    level: error
+`
+
+const testProjectConfig = `
+include: common.yaml
+rootLogger:
+  level: info
+loggers:
+  foo/bar:
+    level: debug
+`
+
+const testCommonConfig = `
+writers:
+  stderr:
+    encoder: console
+loggers:
+  foo:
+    level: info
+    outputs:
+      - stderr
+`
+
+const testEnvConfig = `
+include:
+  - env.d/file.yaml
+loggers:
+  foo:
+`
+
+const testEnvFileConfig = `
+writers:
+  file:
+    path: /tmp/app.log
+    encoder: json
+`
+
+func TestLoadFiles(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "system.yaml"), testSystemConfig)
+	writeFile(t, filepath.Join(dir, "project.yaml"), testProjectConfig)
+	writeFile(t, filepath.Join(dir, "common.yaml"), testCommonConfig)
+	writeFile(t, filepath.Join(dir, "env.yaml"), testEnvConfig)
+	writeFile(t, filepath.Join(dir, "env.d", "file.yaml"), testEnvFileConfig)
+
+	var config loggingConfig
+	assert.NoError(t, loadFiles([]string{
+		filepath.Join(dir, "system.yaml"),
+		filepath.Join(dir, "project.yaml"),
+		filepath.Join(dir, "env.yaml"),
+	}, &defaultFramework{}, &config))
+
+	assert.NotNil(t, config.Encoders.JSON.Fields.Message)
+	assert.NotNil(t, config.Encoders.JSON.Fields.Level)
+	assert.Equal(t, JSONEncoding, config.Writers.Stdout.Encoder)
+	assert.Equal(t, ConsoleEncoding, config.Writers.Stderr.Encoder)
+	assert.Equal(t, "/tmp/app.log", config.Writers.Files["file"].Path)
+
+	assert.Equal(t, InfoLevel, config.RootLogger.Level.Level())
+	assert.Contains(t, config.RootLogger.Outputs.Outputs, "stdout")
+	assert.Equal(t, InfoLevel, config.Loggers["foo"].Level.Level())
+	assert.Contains(t, config.Loggers["foo"].Outputs.Outputs, "stderr")
+	assert.Equal(t, DebugLevel, config.Loggers["foo/bar"].Level.Level())
+
+	writeFile(t, filepath.Join(dir, "a.yaml"), "include: b.yaml\n")
+	writeFile(t, filepath.Join(dir, "b.yaml"), "include: a.yaml\n")
+	assert.Error(t, loadFile(filepath.Join(dir, "a.yaml"), &loggingConfig{}))
+
+	writeFile(t, filepath.Join(dir, "list.yaml"), "- foo\n")
+	assert.Error(t, loadFile(filepath.Join(dir, "list.yaml"), &loggingConfig{}))
+}
+
+func TestConfigPaths(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+
+	t.Setenv(configEnv, filepath.Join(dir, "env.yaml"))
+	paths, err := configPaths()
+	assert.NoError(t, err)
+	assert.NotContains(t, paths, configFile)
+	assert.NotContains(t, paths, filepath.Join(dir, "env.yaml"))
+
+	writeFile(t, filepath.Join(dir, configFile), testProjectConfig)
+	writeFile(t, filepath.Join(dir, "env.yaml"), testEnvConfig)
+	paths, err = configPaths()
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, len(paths), 2)
+	assert.Equal(t, configFile, paths[len(paths)-2])
+	assert.Equal(t, filepath.Join(dir, "env.yaml"), paths[len(paths)-1])
+}
+
+func TestLoadAutoConfigure(t *testing.T) {
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+	writeFile(t, filepath.Join(dir, configFile), "rootLogger:\n  level: verbose\n")
+
+	t.Setenv(autoConfigureEnv, "true")
+	assert.Error(t, load(&defaultFramework{}, &loggingConfig{}))
+
+	t.Setenv(autoConfigureEnv, "false")
+	var config loggingConfig
+	assert.NoError(t, load(&defaultFramework{}, &config))
+	assert.Equal(t, EmptyLevel, config.RootLogger.Level.Level())
+
+	t.Setenv(autoConfigureEnv, "")
+	DisableAutoConfigure()
+	defer autoConfigureDisabled.Store(false)
+	assert.NoError(t, load(&defaultFramework{}, &loggingConfig{}))
+
+	defer resetRootLogger()()
+	defer autoConfigured.Store(false)
+	assert.NotPanics(t, func() {
+		Register(&defaultFramework{})
+	})
+	assert.False(t, AutoConfigured())
+
+	autoConfigureDisabled.Store(false)
+	assert.Panics(t, func() {
+		Register(&defaultFramework{})
+	})
+	assert.True(t, AutoConfigured())
+}
+
+func TestLoadFS(t *testing.T) {
+	defer resetRootLogger()()
+	defer func() {
+		baseConfig = nil
+	}()
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+	assert.NoError(t, configure(&defaultFramework{}, loggingConfig{}, open))
+
+	fsys := fstest.MapFS{
+		"config/logging.yaml": {Data: []byte("include: levels.toml\nrootLogger:\n  level: info\n")},
+		"config/levels.toml":  {Data: []byte("[loggers.fs]\nlevel = \"warn\"\n[loggers.file]\nlevel = \"warn\"\n[loggers.data]\nlevel = \"warn\"\n")},
+	}
+	writeFile(t, filepath.Join(dir, configFile), "loggers:\n  file:\n    level: error\n")
+	t.Setenv(configDataEnv, "loggers:\n  data:\n    level: debug\n")
+
+	// The file system configuration is the base layer, overridden by the configuration files and inline configuration
+	assert.NoError(t, LoadFS(fsys, "./config/logging.yaml"))
+	assert.Equal(t, InfoLevel, GetRootLogger().Level())
+	assert.Equal(t, WarnLevel, GetLogger("fs").Level())
+	assert.Equal(t, ErrorLevel, GetLogger("file").Level())
+	assert.Equal(t, DebugLevel, GetLogger("data").Level())
+
+	// The base layer is kept when the configuration is loaded again
+	var config loggingConfig
+	assert.NoError(t, load(&defaultFramework{}, &config))
+	assert.Equal(t, WarnLevel, config.Loggers["fs"].Level.Level())
+	assert.Equal(t, DebugLevel, config.Loggers["data"].Level.Level())
+
+	t.Setenv(autoConfigureEnv, "false")
+	config = loggingConfig{}
+	assert.NoError(t, load(&defaultFramework{}, &config))
+	assert.Equal(t, WarnLevel, config.Loggers["fs"].Level.Level())
+	assert.Equal(t, WarnLevel, config.Loggers["data"].Level.Level())
+	t.Setenv(autoConfigureEnv, "")
+
+	assert.Error(t, LoadFS(fsys, "missing.yaml"))
+	assert.Equal(t, "config/logging.yaml", baseConfig.path)
+
+	t.Setenv(configDataEnv, "rootLogger:\n  level: loud\n")
+	assert.EqualError(t, load(&defaultFramework{}, &loggingConfig{}), configDataEnv+":2:10: unknown level 'loud'")
+
+	t.Setenv(configFormatEnv, "json")
+	t.Setenv(configDataEnv, `{"rootLogger": {"level": "error"}}`)
+	config = loggingConfig{}
+	assert.NoError(t, load(&defaultFramework{}, &config))
+	assert.Equal(t, ErrorLevel, config.RootLogger.Level.Level())
+}
+
+func writeFile(t *testing.T, path string, data string) {
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+	assert.NoError(t, os.WriteFile(path, []byte(data), 0644))
+}
@@ -5,16 +5,33 @@
 package dazl
 
 import (
+	"context"
 	"fmt"
 	"io"
+	"os"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
+	"sync/atomic"
+	"time"
 )
 
 var root Logger
 
 func init() {
+	root = newRootLogger()
+
+	// Configure the built-in framework so logs are written even if no framework is registered.
+	// Configuration errors are reported when a framework is registered.
+	var config loggingConfig
+	if err := load(&defaultFramework{}, &config); err == nil {
+		_ = configure(&defaultFramework{}, config, open)
+	}
+}
+
+// newRootLogger returns a root logger without outputs
+func newRootLogger() *dazlLogger {
 	logger, err := newLogger(&loggingContext{
 		framework: &defaultFramework{},
 		encoders:  map[Encoding]Encoder{},
@@ -22,7 +39,7 @@ func init() {
 	if err != nil {
 		panic(err)
 	}
-	root = logger
+	return logger
 }
 
 const pathSep = "/"
@@ -66,15 +83,40 @@ type Logger interface {
 	// Level returns the logger level
 	Level() Level
 
-	// SetLevel sets the logger level
+	// SetLevel sets the logger level. Descendants without a level of their own inherit the level.
+	// If the level is empty, the logger inherits the level of its parent.
 	SetLevel(level Level)
 
+	// SetLevelFor sets the logger level for the given duration, after which the level the logger would have had
+	// without the override is restored, whether set for the logger or inherited. Overrides may be nested: the most
+	// recent override that hasn't expired takes precedence over earlier overrides and over levels set with SetLevel
+	// or by reloading the configuration. Calling the returned function restores the level before the override
+	// expires.
+	SetLevelFor(level Level, duration time.Duration) func()
+
+	// WatchLevel calls the given function with the new level each time the logger level changes, including
+	// changes inherited from the logger's ancestors and changes made by reloading the configuration.
+	// The function is called after the change is applied and may be called concurrently for concurrent
+	// changes. Calling the returned function stops watching the level.
+	WatchLevel(f func(Level)) func()
+
+	// GetOutput returns the output of the logger to the named writer, if any. Changes to the output apply until
+	// the configuration is reloaded.
+	GetOutput(writer string) (Output, bool)
+
 	// WithFields adds fields to the logger
 	WithFields(fields ...Field) Logger
 
 	// WithSkipCalls skipsthe given number of calls to the logger methods
 	WithSkipCalls(calls int) Logger
 
+	// WithContext adds the fields and trace span carried by the given context to the logger
+	WithContext(ctx context.Context) Logger
+
+	Trace(...any)
+	Tracef(format string, args ...any)
+	Tracew(msg string, fields ...Field)
+
 	Debug(...any)
 	Debugf(format string, args ...any)
 	Debugw(msg string, fields ...Field)
@@ -98,6 +140,10 @@ type Logger interface {
 	Panic(...any)
 	Panicf(format string, args ...any)
 	Panicw(msg string, fields ...Field)
+
+	// Log logs a message at the given level, which may be a built-in level or a custom level registered
+	// with RegisterLevel
+	Log(level Level, msg string, fields ...Field)
 }
 
 // getCallerPackage gets the package name of the calling function'ss caller
@@ -116,84 +162,76 @@ func getCallerPackage() (string, bool) {
 	return pkg, true
 }
 
+// configure applies the given configuration to the root logger and all existing loggers, so loggers obtained
+// before the configuration is applied, e.g. package loggers, use the new framework and configuration
 func configure(framework Framework, config loggingConfig, opener func(path string) (io.Writer, error)) error {
 	context, err := newLoggingContext(framework, config, opener)
 	if err != nil {
 		return err
 	}
-	logger, err := newLogger(context, nil, "")
+	return root.(*dazlLogger).reconfigure(context)
+}
+
+func newLogger(context *loggingContext, parent *dazlLogger, name string) (*dazlLogger, error) {
+	logger := &dazlLogger{
+		loggerContext: &loggerContext{},
+	}
+	var parentState *loggerState
+	if parent != nil {
+		logger.parent = parent.loggerContext
+		logger.path = append(append([]string{}, parent.path...), name)
+		logger.name = strings.Join(logger.path, pathSep)
+		parentState = parent.getState()
+	}
+	state, level, err := newLoggerState(context, logger.name, parentState)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	root = logger
-	return nil
+	logger.level.Store(int32(level))
+	logger.effectiveLevel.Store(int32(level))
+	logger.state.Store(state)
+	return logger, nil
 }
 
-func newLogger(context *loggingContext, parent *dazlLogger, name string) (*dazlLogger, error) {
+// newLoggerState creates the state for the named logger from the configuration of the given logging context,
+// returning the state and the configured logger level
+func newLoggerState(context *loggingContext, name string, parent *loggerState) (*loggerState, Level, error) {
 	var config loggerConfig
-	var logger *dazlLogger
-	var path []string
+	state := &loggerState{
+		loggingContext: context,
+		outputs:        make(map[string]*dazlOutput),
+	}
 	if parent != nil {
-		path = append(parent.path, name)
-		loggerName := strings.Join(path, pathSep)
-		config, _ = context.config.getLogger(loggerName)
-		defaultLevel := parent.defaultLevel
-		if parent.level != EmptyLevel {
-			defaultLevel = parent.level
-		}
-		logger = &dazlLogger{
-			loggerContext: &loggerContext{
-				loggingContext: context,
-				name:           loggerName,
-				path:           path,
-				defaultLevel:   defaultLevel,
-				sampler:        parent.sampler,
-			},
-			outputs: make(map[string]*dazlOutput),
+		var pattern string
+		config, pattern, _ = context.config.getLogger(name, parent.patterns)
+		state.patterns = parent.patterns
+		if pattern != "" {
+			state.patterns = append(append([]string{}, parent.patterns...), pattern)
 		}
-
+		state.sampler = parent.sampler
 		for outputName, output := range parent.outputs {
-			logger.outputs[outputName] = output.WithWriter(output.writer.WithName(loggerName))
+			state.outputs[outputName] = newOutput(output.writer.WithName(name), output.level, output.sampler)
 		}
 	} else {
-		config = context.config.RootLogger
-		logger = &dazlLogger{
-			loggerContext: &loggerContext{
-				loggingContext: context,
-				sampler:        &allSampler{},
-			},
-			outputs: make(map[string]*dazlOutput),
-		}
+		config = context.config.getRootLogger()
+		state.sampler = &allSampler{}
 	}
 
-	level := config.Level.Level()
-	if level != EmptyLevel {
-		logger.level = level
-	}
-
-	if config.Sample.Basic != nil {
-		logger.sampler = &basicSampler{
-			Interval: uint32(config.Sample.Basic.Interval),
-			MinLevel: config.Sample.Basic.MaxLevel.Level(),
-		}
-	} else if config.Sample.Random != nil {
-		logger.sampler = randomSampler{
-			Interval: config.Sample.Random.Interval,
-			MinLevel: config.Sample.Random.MaxLevel.Level(),
-		}
+	if sampler := config.Sample.newSampler(); sampler != nil {
+		state.sampler = sampler
 	}
 
 	for writerName, outputConfig := range config.Outputs.Outputs {
 		// If the configured output already exists, override the output configuration.
 		// Otherwise, create a new output.
-		output, ok := logger.outputs[writerName]
+		output, ok := state.outputs[writerName]
 		if !ok {
 			writer, err := context.getWriter(writerName)
 			if err != nil {
-				return nil, err
+				return nil, EmptyLevel, err
 			}
-			if logger.name != "" {
-				writer = writer.WithName(logger.name)
+			if name != "" {
+				writer = writer.WithName(name)
 			}
 			output = newOutput(writer, EmptyLevel, &allSampler{})
 		}
@@ -211,35 +249,98 @@ func newLogger(context *loggingContext, parent *dazlLogger, name string) (*dazlL
 					outputConfig.Sample.Basic.Interval,
 					outputConfig.Sample.Basic.MaxLevel.Level())
 				if err != nil {
-					return nil, err
+					return nil, EmptyLevel, err
 				}
 				output = output.WithWriter(writer)
 			} else {
-				output = output.WithSampler(&basicSampler{
-					Interval: uint32(outputConfig.Sample.Basic.Interval),
-					MinLevel: outputConfig.Sample.Basic.MaxLevel.Level(),
-				})
+				output = output.WithSampler(outputConfig.Sample.newSampler())
 			}
 		} else if outputConfig.Sample.Random != nil {
 			if samplingWriter, ok := output.writer.(RandomSamplingWriter); ok {
 				writer, err := samplingWriter.WithRandomSampler(outputConfig.Sample.Random.Interval, outputConfig.Sample.Random.MaxLevel.Level())
 				if err != nil {
-					return nil, err
+					return nil, EmptyLevel, err
 				}
 				output = output.WithWriter(writer)
 			} else {
-				output = output.WithSampler(randomSampler{
-					Interval: outputConfig.Sample.Random.Interval,
-					MinLevel: outputConfig.Sample.Random.MaxLevel.Level(),
-				})
+				output = output.WithSampler(outputConfig.Sample.newSampler())
 			}
 		}
-		logger.outputs[writerName] = output
+		state.outputs[writerName] = output
 	}
-	return logger, nil
+	return state, config.Level.Level(), nil
+}
+
+// reconfigure applies the configuration of the given logging context to the logger and all its descendants.
+// The states of all loggers are created before any are updated, so the loggers are left unchanged on error.
+func (l *dazlLogger) reconfigure(context *loggingContext) error {
+	previous, changes, err := l.apply(context)
+	if err != nil {
+		context.close()
+		return err
+	}
+	// The files opened for the previous configuration are no longer written by any logger
+	if previous != context {
+		previous.close()
+	}
+	notifyLevelChanges(changes)
+	return nil
+}
+
+// apply updates the states of the logger and all its descendants, returning the previous logging context
+// of the logger and the resulting level changes
+func (l *dazlLogger) apply(context *loggingContext) (*loggingContext, []levelChange, error) {
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
+	var updates []loggerUpdate
+	if err := l.prepare(context, nil, &updates); err != nil {
+		return nil, nil, err
+	}
+	previous := l.getState().loggingContext
+	for _, update := range updates {
+		update.logger.state.Store(update.state)
+		update.logger.level.Store(int32(update.level))
+	}
+	var changes []levelChange
+	l.updateLevel(&changes)
+	return previous, changes, nil
+}
+
+// loggerUpdate is a pending update to the state of a logger
+type loggerUpdate struct {
+	logger *dazlLogger
+	state  *loggerState
+	level  Level
+}
+
+// prepare creates updates for the logger and all its descendants from the given logging context
+func (l *dazlLogger) prepare(context *loggingContext, parent *loggerState, updates *[]loggerUpdate) error {
+	state, level, err := newLoggerState(context, l.name, parent)
+	if err != nil {
+		return err
+	}
+	*updates = append(*updates, loggerUpdate{
+		logger: l,
+		state:  state,
+		level:  level,
+	})
+	l.children.Range(func(key, value any) bool {
+		err = value.(*dazlLogger).prepare(context, state, updates)
+		return err == nil
+	})
+	return err
 }
 
 func newLoggingContext(framework Framework, config loggingConfig, opener func(path string) (io.Writer, error)) (*loggingContext, error) {
+	levels, err := envLevels(os.Environ())
+	if err != nil {
+		return nil, err
+	}
+	config.levels = levels
+	if config.patterns, err = newLoggerPatterns(config.Loggers); err != nil {
+		return nil, err
+	}
+
 	encoders := make(map[Encoding]Encoder)
 	if consoleEncodingFramework, ok := framework.(ConsoleEncodingFramework); ok {
 		encoder, err := configureConsoleEncoder(config.Encoders.Console, consoleEncodingFramework.ConsoleEncoder())
@@ -255,12 +356,52 @@ func newLoggingContext(framework Framework, config loggingConfig, opener func(pa
 		}
 		encoders[JSONEncoding] = encoder
 	}
-	return &loggingContext{
+	context := &loggingContext{
 		framework: framework,
 		config:    config,
 		encoders:  encoders,
 		opener:    opener,
-	}, nil
+		traceKeys: map[Encoding]traceKeys{
+			ConsoleEncoding: newTraceKeys(config.Encoders.Console.Fields),
+			JSONEncoding:    newTraceKeys(config.Encoders.JSON.Fields),
+		},
+	}
+	if err := context.checkOutputs(); err != nil {
+		return nil, err
+	}
+	return context, nil
+}
+
+// checkOutputs checks that the writers of all configured logger outputs can be created, including the outputs
+// of loggers that don't exist yet, so loggers can be created without errors once the configuration is applied
+func (c *loggingContext) checkOutputs() error {
+	if err := c.checkLoggerOutputs("root logger", c.config.RootLogger); err != nil {
+		return err
+	}
+	names := make([]string, 0, len(c.config.Loggers))
+	for name := range c.config.Loggers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := c.checkLoggerOutputs(fmt.Sprintf("logger '%s'", name), c.config.Loggers[name]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *loggingContext) checkLoggerOutputs(logger string, config loggerConfig) error {
+	for name := range config.Outputs.Outputs {
+		encoding, ok := c.config.Writers.getEncoding(name)
+		if !ok {
+			return fmt.Errorf("%s output references undefined writer '%s'", logger, name)
+		}
+		if _, ok := c.encoders[encoding]; !ok {
+			return fmt.Errorf("%s framework does not support %s encoding", c.framework.Name(), encoding)
+		}
+	}
+	return nil
 }
 
 type loggingContext struct {
@@ -268,8 +409,30 @@ type loggingContext struct {
 	config    loggingConfig
 	opener    func(path string) (io.Writer, error)
 	encoders  map[Encoding]Encoder
+	traceKeys map[Encoding]traceKeys
 	writers   sync.Map
-	mu        sync.Mutex
+	// files are the files opened for the writers, closed when the configuration is replaced
+	files []io.Closer
+	mu    sync.Mutex
+}
+
+// close closes the files opened for the writers
+func (c *loggingContext) close() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, file := range c.files {
+		_ = file.Close()
+	}
+	c.files = nil
+}
+
+// getTraceKeys returns the trace field keys for the encoding of the named writer
+func (c *loggingContext) getTraceKeys(name string) traceKeys {
+	encoding, _ := c.config.Writers.getEncoding(name)
+	if keys, ok := c.traceKeys[encoding]; ok {
+		return keys
+	}
+	return newTraceKeys(encoderFieldsConfig{})
 }
 
 func (c *loggingContext) getWriter(name string) (Writer, error) {
@@ -328,32 +491,169 @@ func (c *loggingContext) newWriter(name string) (Writer, error) {
 		if !ok {
 			return nil, fmt.Errorf("'%s' writer is not configured", name)
 		}
+		encoder, ok := c.encoders[config.Encoder]
+		if !ok {
+			return nil, fmt.Errorf("%s framework does not support %s encoding", c.framework.Name(), config.Encoder)
+		}
 		writer, err := c.opener(config.Path)
 		if err != nil {
 			return nil, err
 		}
-		encoder, ok := c.encoders[config.Encoder]
-		if !ok {
-			return nil, fmt.Errorf("%s framework does not support %s encoding", c.framework.Name(), config.Encoder)
+		if file, ok := writer.(io.Closer); ok {
+			c.files = append(c.files, file)
 		}
 		return encoder.NewWriter(writer)
 	}
 }
 
+// loggersMu serializes changes to loggers: creating loggers and changing their levels, outputs and states.
+// Loggers are read without locking: each logger stores its effective level, which is its own level if set or
+// the effective level of its parent otherwise, and its state, which is replaced rather than modified.
+var loggersMu sync.Mutex
+
 type loggerContext struct {
+	name     string
+	path     []string
+	parent   *loggerContext
+	children sync.Map
+	// level is the level set for the logger, or the empty level if the logger inherits its parent's level
+	level atomic.Int32
+	// overrides are the levels set with SetLevelFor that haven't expired, most recent last
+	overrides []*levelOverride
+	// effectiveLevel is the level of the logger after overrides and inheritance
+	effectiveLevel atomic.Int32
+	state          atomic.Value
+	watchersMu     sync.Mutex
+	watchers       map[uint64]func(Level)
+	nextWatcher    uint64
+}
+
+// levelOverride is a level set for a logger with SetLevelFor
+type levelOverride struct {
+	level Level
+}
+
+// levelChange is a change to the effective level of a logger
+type levelChange struct {
+	logger *loggerContext
+	level  Level
+}
+
+// updateLevel updates the effective levels of the logger and its descendants, recording the loggers
+// whose effective level changed. loggersMu must be held.
+func (c *loggerContext) updateLevel(changes *[]levelChange) {
+	level := Level(c.level.Load())
+	if len(c.overrides) > 0 {
+		level = c.overrides[len(c.overrides)-1].level
+	}
+	if level == EmptyLevel && c.parent != nil {
+		level = Level(c.parent.effectiveLevel.Load())
+	}
+	if Level(c.effectiveLevel.Swap(int32(level))) != level {
+		*changes = append(*changes, levelChange{
+			logger: c,
+			level:  level,
+		})
+	}
+	c.children.Range(func(key, value any) bool {
+		value.(*dazlLogger).updateLevel(changes)
+		return true
+	})
+}
+
+// notifyLevelChanges calls the level watchers of the changed loggers. Watchers are called without holding
+// loggersMu so they may change levels themselves.
+func notifyLevelChanges(changes []levelChange) {
+	for _, change := range changes {
+		change.logger.watchersMu.Lock()
+		watchers := make([]func(Level), 0, len(change.logger.watchers))
+		for _, watcher := range change.logger.watchers {
+			watchers = append(watchers, watcher)
+		}
+		change.logger.watchersMu.Unlock()
+		for _, watcher := range watchers {
+			watcher(change.level)
+		}
+	}
+}
+
+// getState returns the current state of the logger
+func (c *loggerContext) getState() *loggerState {
+	return c.state.Load().(*loggerState)
+}
+
+// loggerState is the state of a logger created from the configuration of a logging context.
+// The state of a logger is replaced when the logging context is reconfigured.
+type loggerState struct {
 	*loggingContext
-	name         string
-	path         []string
-	children     sync.Map
-	mu           sync.Mutex
-	level        Level
-	defaultLevel Level
-	sampler      Sampler
+	sampler Sampler
+	outputs map[string]*dazlOutput
+	// patterns are the keys of the logger patterns applied to the logger and its ancestors
+	patterns []string
+}
+
+// outputDecorator decorates the writer for the named output of a logger
+type outputDecorator func(name string, writer Writer) Writer
+
+// loggerOutputs are the outputs of a logger derived from a logger state
+type loggerOutputs struct {
+	state   *loggerState
+	outputs map[string]*dazlOutput
 }
 
 type dazlLogger struct {
 	*loggerContext
-	outputs map[string]*dazlOutput
+	decorators []outputDecorator
+	outputs    atomic.Value
+}
+
+// getOutputs returns the outputs of the logger, deriving them from the current logger state if the
+// state has changed since the outputs were last derived
+func (l *dazlLogger) getOutputs() map[string]*dazlOutput {
+	state := l.getState()
+	if len(l.decorators) == 0 {
+		return state.outputs
+	}
+	if outputs, ok := l.outputs.Load().(*loggerOutputs); ok && outputs.state == state {
+		return outputs.outputs
+	}
+	outputs := decorate(state.outputs, l.decorators...)
+	l.outputs.Store(&loggerOutputs{
+		state:   state,
+		outputs: outputs,
+	})
+	return outputs
+}
+
+// withDecorator returns a copy of the logger with the given output decorator
+func (l *dazlLogger) withDecorator(decorator outputDecorator) *dazlLogger {
+	state := l.getState()
+	parent := l.getOutputs()
+	decorators := make([]outputDecorator, 0, len(l.decorators)+1)
+	decorators = append(decorators, l.decorators...)
+	decorators = append(decorators, decorator)
+	logger := &dazlLogger{
+		loggerContext: l.loggerContext,
+		decorators:    decorators,
+	}
+	logger.outputs.Store(&loggerOutputs{
+		state:   state,
+		outputs: decorate(parent, decorator),
+	})
+	return logger
+}
+
+// decorate applies the given decorators to the writers of the given outputs
+func decorate(outputs map[string]*dazlOutput, decorators ...outputDecorator) map[string]*dazlOutput {
+	decorated := make(map[string]*dazlOutput)
+	for name, output := range outputs {
+		writer := output.writer
+		for _, decorator := range decorators {
+			writer = decorator(name, writer)
+		}
+		decorated[name] = output.WithWriter(writer)
+	}
+	return decorated
 }
 
 func (l *dazlLogger) Name() string {
@@ -361,27 +661,71 @@ func (l *dazlLogger) Name() string {
 }
 
 func (l *dazlLogger) Level() Level {
-	if l.level != EmptyLevel {
-		return l.level
-	}
-	return l.defaultLevel
+	return Level(l.effectiveLevel.Load())
 }
 
 func (l *dazlLogger) SetLevel(level Level) {
-	l.level = level
-	l.children.Range(func(key, value any) bool {
-		value.(*dazlLogger).setDefaultLevel(level)
-		return true
-	})
+	loggersMu.Lock()
+	l.level.Store(int32(level))
+	var changes []levelChange
+	l.updateLevel(&changes)
+	loggersMu.Unlock()
+	notifyLevelChanges(changes)
+}
+
+func (l *dazlLogger) SetLevelFor(level Level, duration time.Duration) func() {
+	override := &levelOverride{
+		level: level,
+	}
+	loggersMu.Lock()
+	l.overrides = append(l.overrides, override)
+	var changes []levelChange
+	l.updateLevel(&changes)
+	loggersMu.Unlock()
+	notifyLevelChanges(changes)
+
+	var once sync.Once
+	restore := func() {
+		once.Do(func() {
+			l.removeOverride(override)
+		})
+	}
+	timer := time.AfterFunc(duration, restore)
+	return func() {
+		timer.Stop()
+		restore()
+	}
 }
 
-func (l *dazlLogger) setDefaultLevel(level Level) {
-	if l.level == EmptyLevel {
-		l.defaultLevel = level
-		l.children.Range(func(key, value any) bool {
-			value.(*dazlLogger).setDefaultLevel(level)
-			return true
-		})
+// removeOverride removes the given level override from the logger
+func (c *loggerContext) removeOverride(override *levelOverride) {
+	loggersMu.Lock()
+	overrides := make([]*levelOverride, 0, len(c.overrides))
+	for _, existing := range c.overrides {
+		if existing != override {
+			overrides = append(overrides, existing)
+		}
+	}
+	c.overrides = overrides
+	var changes []levelChange
+	c.updateLevel(&changes)
+	loggersMu.Unlock()
+	notifyLevelChanges(changes)
+}
+
+func (l *dazlLogger) WatchLevel(f func(Level)) func() {
+	l.watchersMu.Lock()
+	defer l.watchersMu.Unlock()
+	if l.watchers == nil {
+		l.watchers = make(map[uint64]func(Level))
+	}
+	id := l.nextWatcher
+	l.nextWatcher++
+	l.watchers[id] = f
+	return func() {
+		l.watchersMu.Lock()
+		defer l.watchersMu.Unlock()
+		delete(l.watchers, id)
 	}
 }
 
@@ -407,63 +751,104 @@ func (l *dazlLogger) getChild(name string) (*dazlLogger, error) {
 		return child.(*dazlLogger), nil
 	}
 
-	l.mu.Lock()
-	defer l.mu.Unlock()
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
 
 	child, ok = l.children.Load(name)
 	if ok {
 		return child.(*dazlLogger), nil
 	}
 
-	logger, err := newLogger(l.loggingContext, l, name)
+	logger, err := newLogger(l.getState().loggingContext, l, name)
 	if err != nil {
 		return nil, err
 	}
-
+	var changes []levelChange
+	logger.updateLevel(&changes)
 	l.children.Store(name, logger)
 	return logger, nil
 }
 
+func (l *dazlLogger) GetOutput(writer string) (Output, bool) {
+	if _, ok := l.getState().outputs[writer]; !ok {
+		return nil, false
+	}
+	return &loggerOutput{
+		logger: l.loggerContext,
+		name:   writer,
+	}, true
+}
+
 func (l *dazlLogger) WithFields(fields ...Field) Logger {
-	outputs := make(map[string]*dazlOutput)
-	for name, output := range l.outputs {
-		writer := output.writer
+	return l.withOutputFields(func(string) []Field {
+		return fields
+	})
+}
+
+// withOutputFields adds the fields returned by the given function for each named output to the logger
+func (l *dazlLogger) withOutputFields(outputFields func(name string) []Field) Logger {
+	return l.withDecorator(func(name string, writer Writer) Writer {
 		var err error
-		for _, field := range fields {
+		for _, field := range outputFields(name) {
 			if writer, err = field(writer); err != nil {
 				panic(err)
 			}
 		}
-		outputs[name] = output.WithWriter(writer)
-	}
-	return &dazlLogger{
-		loggerContext: l.loggerContext,
-		outputs:       outputs,
-	}
+		return writer
+	})
 }
 
 func (l *dazlLogger) WithSkipCalls(calls int) Logger {
-	outputs := make(map[string]*dazlOutput)
-	for name, output := range l.outputs {
-		outputs[name] = output.WithWriter(output.writer.WithSkipCalls(calls))
+	return l.withDecorator(func(name string, writer Writer) Writer {
+		return writer.WithSkipCalls(calls)
+	})
+}
+
+func (l *dazlLogger) WithContext(ctx context.Context) Logger {
+	fields := FieldsFromContext(ctx)
+	spanContext, ok := spanContextFromContext(ctx)
+	if !ok {
+		if len(fields) == 0 {
+			return l
+		}
+		return l.WithFields(fields...)
 	}
-	return &dazlLogger{
-		loggerContext: l.loggerContext,
-		outputs:       outputs,
+	return l.withOutputFields(func(name string) []Field {
+		return append(l.getState().getTraceKeys(name).fields(spanContext), fields...)
+	})
+}
+
+func (l *dazlLogger) Trace(args ...any) {
+	if l.Level().Enabled(TraceLevel) && l.getState().sampler.Sample(TraceLevel) {
+		for _, output := range l.getOutputs() {
+			output.Trace(fmt.Sprint(args...))
+		}
 	}
 }
 
+func (l *dazlLogger) Tracef(format string, args ...any) {
+	if l.Level().Enabled(TraceLevel) && l.getState().sampler.Sample(TraceLevel) {
+		for _, output := range l.getOutputs() {
+			output.Trace(fmt.Sprintf(format, args...))
+		}
+	}
+}
+
+func (l *dazlLogger) Tracew(msg string, fields ...Field) {
+	l.WithFields(fields...).WithSkipCalls(1).Trace(msg)
+}
+
 func (l *dazlLogger) Debug(args ...any) {
-	if l.Level().Enabled(DebugLevel) && l.sampler.Sample(DebugLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(DebugLevel) && l.getState().sampler.Sample(DebugLevel) {
+		for _, output := range l.getOutputs() {
 			output.Debug(fmt.Sprint(args...))
 		}
 	}
 }
 
 func (l *dazlLogger) Debugf(format string, args ...any) {
-	if l.Level().Enabled(DebugLevel) && l.sampler.Sample(DebugLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(DebugLevel) && l.getState().sampler.Sample(DebugLevel) {
+		for _, output := range l.getOutputs() {
 			output.Debug(fmt.Sprintf(format, args...))
 		}
 	}
@@ -474,16 +859,16 @@ func (l *dazlLogger) Debugw(msg string, fields ...Field) {
 }
 
 func (l *dazlLogger) Info(args ...any) {
-	if l.Level().Enabled(InfoLevel) && l.sampler.Sample(InfoLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(InfoLevel) && l.getState().sampler.Sample(InfoLevel) {
+		for _, output := range l.getOutputs() {
 			output.Info(fmt.Sprint(args...))
 		}
 	}
 }
 
 func (l *dazlLogger) Infof(format string, args ...any) {
-	if l.Level().Enabled(InfoLevel) && l.sampler.Sample(InfoLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(InfoLevel) && l.getState().sampler.Sample(InfoLevel) {
+		for _, output := range l.getOutputs() {
 			output.Info(fmt.Sprintf(format, args...))
 		}
 	}
@@ -494,16 +879,16 @@ func (l *dazlLogger) Infow(msg string, fields ...Field) {
 }
 
 func (l *dazlLogger) Warn(args ...any) {
-	if l.Level().Enabled(WarnLevel) && l.sampler.Sample(WarnLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(WarnLevel) && l.getState().sampler.Sample(WarnLevel) {
+		for _, output := range l.getOutputs() {
 			output.Warn(fmt.Sprint(args...))
 		}
 	}
 }
 
 func (l *dazlLogger) Warnf(format string, args ...any) {
-	if l.Level().Enabled(WarnLevel) && l.sampler.Sample(WarnLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(WarnLevel) && l.getState().sampler.Sample(WarnLevel) {
+		for _, output := range l.getOutputs() {
 			output.Warn(fmt.Sprintf(format, args...))
 		}
 	}
@@ -514,16 +899,16 @@ func (l *dazlLogger) Warnw(msg string, fields ...Field) {
 }
 
 func (l *dazlLogger) Error(args ...any) {
-	if l.Level().Enabled(ErrorLevel) && l.sampler.Sample(ErrorLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(ErrorLevel) && l.getState().sampler.Sample(ErrorLevel) {
+		for _, output := range l.getOutputs() {
 			output.Error(fmt.Sprint(args...))
 		}
 	}
 }
 
 func (l *dazlLogger) Errorf(format string, args ...any) {
-	if l.Level().Enabled(ErrorLevel) && l.sampler.Sample(ErrorLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(ErrorLevel) && l.getState().sampler.Sample(ErrorLevel) {
+		for _, output := range l.getOutputs() {
 			output.Error(fmt.Sprintf(format, args...))
 		}
 	}
@@ -534,16 +919,16 @@ func (l *dazlLogger) Errorw(msg string, fields ...Field) {
 }
 
 func (l *dazlLogger) Fatal(args ...any) {
-	if l.Level().Enabled(FatalLevel) && l.sampler.Sample(FatalLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(FatalLevel) && l.getState().sampler.Sample(FatalLevel) {
+		for _, output := range l.getOutputs() {
 			output.Fatal(fmt.Sprint(args...))
 		}
 	}
 }
 
 func (l *dazlLogger) Fatalf(format string, args ...any) {
-	if l.Level().Enabled(FatalLevel) && l.sampler.Sample(FatalLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(FatalLevel) && l.getState().sampler.Sample(FatalLevel) {
+		for _, output := range l.getOutputs() {
 			output.Fatal(fmt.Sprintf(format, args...))
 		}
 	}
@@ -554,16 +939,16 @@ func (l *dazlLogger) Fatalw(msg string, fields ...Field) {
 }
 
 func (l *dazlLogger) Panic(args ...any) {
-	if l.Level().Enabled(PanicLevel) && l.sampler.Sample(PanicLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(PanicLevel) && l.getState().sampler.Sample(PanicLevel) {
+		for _, output := range l.getOutputs() {
 			output.Panic(fmt.Sprint(args...))
 		}
 	}
 }
 
 func (l *dazlLogger) Panicf(format string, args ...any) {
-	if l.Level().Enabled(PanicLevel) && l.sampler.Sample(PanicLevel) {
-		for _, output := range l.outputs {
+	if l.Level().Enabled(PanicLevel) && l.getState().sampler.Sample(PanicLevel) {
+		for _, output := range l.getOutputs() {
 			output.Panic(fmt.Sprintf(format, args...))
 		}
 	}
@@ -573,6 +958,20 @@ func (l *dazlLogger) Panicw(msg string, fields ...Field) {
 	l.WithFields(fields...).WithSkipCalls(1).Panic(msg)
 }
 
+func (l *dazlLogger) Log(level Level, msg string, fields ...Field) {
+	l.WithFields(fields...).WithSkipCalls(1).(*dazlLogger).log(level, msg)
+}
+
+// log writes the message at the given level, writing entries at unfiltered custom levels regardless of the
+// logger level and sampling
+func (l *dazlLogger) log(level Level, msg string) {
+	if level.unfiltered() || l.Level().Enabled(level) && l.getState().sampler.Sample(level) {
+		for _, output := range l.getOutputs() {
+			output.Log(level, msg)
+		}
+	}
+}
+
 var _ Logger = &dazlLogger{}
 
 type loggerConfig struct {
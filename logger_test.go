@@ -6,6 +6,7 @@ package dazl
 
 import (
 	"bytes"
+	"fmt"
 	fuzz "github.com/AdaLogics/go-fuzz-headers"
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -13,9 +14,20 @@ import (
 	"io"
 	"sort"
 	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
+// resetRootLogger replaces the root logger with a new root logger until the returned function is called
+func resetRootLogger() func() {
+	logger := root
+	root = newRootLogger()
+	return func() {
+		root = logger
+	}
+}
+
 func TestLoggerNames(t *testing.T) {
 	assert.Equal(t, "", root.Name())
 	assert.Equal(t, "foo", GetLogger("foo").Name())
@@ -42,6 +54,295 @@ func TestLoggerLevels(t *testing.T) {
 	assert.Equal(t, InfoLevel, GetLogger("foo/bar/baz").Level())
 }
 
+const testConfigureConfig = `
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: error
+  outputs:
+    - stdout
+`
+
+func TestConfigureExistingLoggers(t *testing.T) {
+	defer resetRootLogger()()
+
+	// Loggers obtained before the configuration is applied use the new configuration and framework
+	log := GetLogger("configure/foo")
+	assert.Equal(t, "default", log.(*dazlLogger).getState().framework.Name())
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testConfigureConfig), &config))
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&testFramework{json: newDefaultJSONEncoder(defaultEncoderConfig{})}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+	assert.Equal(t, "test", log.(*dazlLogger).getState().framework.Name())
+	assert.Equal(t, ErrorLevel, log.Level())
+	log.Warn("Hello world!")
+	assert.Empty(t, buf.String())
+	log.Error("Hello world!")
+	assert.Contains(t, buf.String(), "Hello world!")
+
+	GetRootLogger().SetLevel(WarnLevel)
+	assert.Equal(t, WarnLevel, log.Level())
+}
+
+const testReconfigureFilesConfig = `
+writers:
+  file:
+    encoder: json
+    path: app.log
+rootLogger:
+  level: info
+  outputs:
+    - file
+`
+
+func TestReconfigureClosesFiles(t *testing.T) {
+	defer resetRootLogger()()
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testReconfigureFilesConfig), &config))
+	var files []*testFile
+	opener := func(path string) (io.Writer, error) {
+		file := &testFile{}
+		files = append(files, file)
+		return file, nil
+	}
+
+	log := GetLogger("reconfigure")
+	assert.NoError(t, configure(&defaultFramework{}, config, opener))
+	log.Info("first")
+	assert.Len(t, files, 1)
+
+	// The files of the previous configuration are closed when the configuration is replaced
+	assert.NoError(t, configure(&defaultFramework{}, config, opener))
+	log.Info("second")
+	assert.Len(t, files, 2)
+	assert.True(t, files[0].closed)
+	assert.False(t, files[1].closed)
+	assert.Contains(t, files[0].String(), "first")
+	assert.NotContains(t, files[0].String(), "second")
+	assert.Contains(t, files[1].String(), "second")
+}
+
+// testFile is a buffer that records whether it's closed
+type testFile struct {
+	bytes.Buffer
+	closed bool
+}
+
+func (f *testFile) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestWatchLevel(t *testing.T) {
+	defer resetRootLogger()()
+	assert.NoError(t, configure(&defaultFramework{}, loggingConfig{}, open))
+
+	var levels []Level
+	log := GetLogger("watch/foo")
+	stop := log.WatchLevel(func(level Level) {
+		levels = append(levels, level)
+	})
+
+	GetRootLogger().SetLevel(InfoLevel)
+	assert.Equal(t, InfoLevel, log.Level())
+	GetLogger("watch").SetLevel(DebugLevel)
+	assert.Equal(t, DebugLevel, log.Level())
+	log.SetLevel(WarnLevel)
+	GetLogger("watch").SetLevel(ErrorLevel)
+	assert.Equal(t, WarnLevel, log.Level())
+
+	// An empty level restores the inherited level
+	log.SetLevel(EmptyLevel)
+	assert.Equal(t, ErrorLevel, log.Level())
+	GetLogger("watch").SetLevel(EmptyLevel)
+	assert.Equal(t, InfoLevel, log.Level())
+	assert.Equal(t, InfoLevel, GetLogger("watch/foo/bar").Level())
+
+	// Reloading the configuration changes the levels of existing loggers
+	context, err := newLoggingContext(&defaultFramework{}, loggingConfig{
+		Loggers: map[string]loggerConfig{
+			"watch": {Level: levelConfig(TraceLevel)},
+		},
+	}, open)
+	assert.NoError(t, err)
+	assert.NoError(t, root.(*dazlLogger).reconfigure(context))
+	assert.Equal(t, TraceLevel, log.Level())
+	assert.Equal(t, EmptyLevel, GetRootLogger().Level())
+
+	stop()
+	log.SetLevel(FatalLevel)
+	assert.Equal(t, []Level{InfoLevel, DebugLevel, WarnLevel, ErrorLevel, InfoLevel, TraceLevel}, levels)
+}
+
+func TestSetLevelFor(t *testing.T) {
+	defer resetRootLogger()()
+	assert.NoError(t, configure(&defaultFramework{}, loggingConfig{}, open))
+
+	var mu sync.Mutex
+	var levels []Level
+	log := GetLogger("override/foo")
+	stop := log.WatchLevel(func(level Level) {
+		mu.Lock()
+		defer mu.Unlock()
+		levels = append(levels, level)
+	})
+	defer stop()
+
+	GetRootLogger().SetLevel(InfoLevel)
+	assert.Equal(t, InfoLevel, log.Level())
+
+	// Nested overrides are restored in any order
+	restoreDebug := GetLogger("override").SetLevelFor(DebugLevel, time.Hour)
+	assert.Equal(t, DebugLevel, log.Level())
+	restoreTrace := GetLogger("override").SetLevelFor(TraceLevel, time.Hour)
+	assert.Equal(t, TraceLevel, log.Level())
+	restoreDebug()
+	assert.Equal(t, TraceLevel, log.Level())
+	restoreTrace()
+	assert.Equal(t, InfoLevel, log.Level())
+	assert.Equal(t, EmptyLevel, Level(GetLogger("override").(*dazlLogger).level.Load()))
+	restoreTrace()
+	assert.Equal(t, InfoLevel, log.Level())
+
+	// Levels set while an override is active take effect when the override expires
+	log.SetLevel(WarnLevel)
+	log.SetLevelFor(DebugLevel, 10*time.Millisecond)
+	assert.Equal(t, DebugLevel, log.Level())
+	log.SetLevel(ErrorLevel)
+	assert.Equal(t, DebugLevel, log.Level())
+	assert.Eventually(t, func() bool {
+		return log.Level() == ErrorLevel
+	}, time.Second, time.Millisecond)
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []Level{InfoLevel, DebugLevel, TraceLevel, InfoLevel, WarnLevel, DebugLevel, ErrorLevel}, levels)
+}
+
+func TestConcurrentLevels(t *testing.T) {
+	defer resetRootLogger()()
+	assert.NoError(t, configure(&defaultFramework{}, loggingConfig{}, open))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				GetLogger("concurrent").SetLevel(loggerLevels[(i+j)%len(loggerLevels)])
+				GetLogger(fmt.Sprintf("concurrent/%d/%d", i, j%10)).Debug("debug")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	GetLogger("concurrent").SetLevel(WarnLevel)
+	for i := 0; i < 4; i++ {
+		for j := 0; j < 10; j++ {
+			assert.Equal(t, WarnLevel, GetLogger(fmt.Sprintf("concurrent/%d/%d", i, j)).Level())
+		}
+	}
+}
+
+const testOutputsConfig = `
+encoders:
+  json:
+    fields:
+      - message
+writers:
+  console:
+    path: console.log
+    encoder: json
+  file:
+    path: app.log
+    encoder: json
+rootLogger:
+  level: debug
+  outputs:
+    console:
+      level: info
+    file:
+      level: info
+loggers:
+  outputs/override:
+    outputs:
+      file:
+        level: warn
+`
+
+func TestLoggerOutputs(t *testing.T) {
+	defer resetRootLogger()()
+	buffers := make(map[string]*bytes.Buffer)
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testOutputsConfig), &config))
+	assert.NoError(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		buffers[path] = &bytes.Buffer{}
+		return buffers[path], nil
+	}))
+	lines := func(path string) int {
+		n := strings.Count(buffers[path].String(), "\n")
+		buffers[path].Reset()
+		return n
+	}
+
+	log := GetLogger("outputs/foo")
+	override := GetLogger("outputs/override")
+	_, ok := log.GetOutput("stdout")
+	assert.False(t, ok)
+
+	// Debug entries are written to the file by loggers inheriting the root logger's output level
+	rootFile, ok := GetRootLogger().GetOutput("file")
+	assert.True(t, ok)
+	assert.Equal(t, "file", rootFile.Name())
+	assert.NoError(t, rootFile.SetLevel(DebugLevel))
+	file, ok := log.GetOutput("file")
+	assert.True(t, ok)
+	assert.Equal(t, DebugLevel, file.Level())
+	log.WithFields(String("foo", "bar")).Debug("debug")
+	assert.Equal(t, 1, lines("app.log"))
+	assert.Equal(t, 0, lines("console.log"))
+	overrideFile, _ := override.GetOutput("file")
+	assert.Equal(t, WarnLevel, overrideFile.Level())
+	override.Info("info")
+	assert.Equal(t, 0, lines("app.log"))
+	assert.Equal(t, 1, lines("console.log"))
+
+	// An empty level restores the inherited level
+	assert.NoError(t, file.SetLevel(ErrorLevel))
+	assert.Equal(t, ErrorLevel, file.Level())
+	assert.Equal(t, ErrorLevel, GetLogger("outputs/foo/bar").(*dazlLogger).getState().outputs["file"].level)
+	assert.NoError(t, file.SetLevel(EmptyLevel))
+	assert.Equal(t, DebugLevel, file.Level())
+	assert.Equal(t, DebugLevel, GetLogger("outputs/foo/bar").(*dazlLogger).getState().outputs["file"].level)
+
+	assert.NoError(t, rootFile.SetSampler(&SamplerConfig{Basic: &BasicSamplerConfig{Interval: 2}}))
+	for i := 0; i < 4; i++ {
+		log.Info("info")
+	}
+	assert.Equal(t, 2, lines("app.log"))
+	assert.Equal(t, 4, lines("console.log"))
+	assert.NoError(t, rootFile.SetSampler(nil))
+	log.Info("info")
+	log.Info("info")
+	assert.Equal(t, 2, lines("app.log"))
+
+	assert.EqualError(t, rootFile.SetSampler(&SamplerConfig{Random: &RandomSamplerConfig{}}),
+		"sampling interval must be a positive integer")
+
+	// Reloading the configuration restores the configured output levels
+	context, err := newLoggingContext(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buffers[path], nil
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, root.(*dazlLogger).reconfigure(context))
+	assert.Equal(t, InfoLevel, file.Level())
+}
+
 const testLoggerConfigArray = `
 level: debug
 sample:
@@ -157,6 +458,7 @@ loggers:
 `
 
 func TestLogger(t *testing.T) {
+	defer resetRootLogger()()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -326,6 +628,7 @@ rootLogger:
 `
 
 func TestLoggerMethods(t *testing.T) {
+	defer resetRootLogger()()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
@@ -355,6 +658,10 @@ func TestLoggerMethods(t *testing.T) {
 	stdout.EXPECT().WithSkipCalls(gomock.Eq(1)).Return(stdout).AnyTimes()
 	log := GetLogger("test")
 
+	log.Trace("trace")
+	log.Tracef("trace")
+	log.Tracew("trace")
+
 	stdout.EXPECT().Debug(gomock.Eq("debug"))
 	log.Debug("debug")
 	stdout.EXPECT().Debug(gomock.Eq("debug"))
@@ -382,6 +689,61 @@ func TestLoggerMethods(t *testing.T) {
 	log.Errorf("error")
 	stdout.EXPECT().Error(gomock.Eq("error"))
 	log.Errorw("error")
+
+	log.Log(TraceLevel, "trace")
+	stdout.EXPECT().Info(gomock.Eq("info"))
+	log.Log(InfoLevel, "info")
+	stdout.EXPECT().Error(gomock.Eq("error"))
+	log.Log(ErrorLevel, "error")
+}
+
+const testCustomLevelsLoggerConfig = `
+writers:
+  stdout:
+    encoder: json
+
+rootLogger:
+  level: error
+  outputs:
+    stdout:
+      level: fatal
+`
+
+func TestLoggerCustomLevels(t *testing.T) {
+	defer resetRootLogger()()
+	assert.NoError(t, RegisterLevel("notice", 35))
+	assert.NoError(t, RegisterLevel("audit", 45, Unfiltered()))
+	notice, _ := parseLevel("notice")
+	audit, _ := parseLevel("audit")
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	json := NewMockEncoder(ctrl)
+	stdout := NewMockWriter(ctrl)
+	stdout.EXPECT().WithSkipCalls(gomock.Eq(2)).Return(stdout)
+	json.EXPECT().NewWriter(gomock.Any()).Return(stdout, nil)
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testCustomLevelsLoggerConfig), &config))
+	assert.NoError(t, configure(&testFramework{json: json}, config, func(path string) (io.Writer, error) {
+		return &bytes.Buffer{}, nil
+	}))
+
+	stdout.EXPECT().WithName(gomock.Any()).Return(stdout).AnyTimes()
+	stdout.EXPECT().WithSkipCalls(gomock.Eq(1)).Return(stdout).AnyTimes()
+	log := GetLogger("test/custom")
+
+	// Unfiltered levels are written regardless of the logger and output levels
+	log.Log(notice, "notice")
+	stdout.EXPECT().Log(gomock.Eq(audit), gomock.Eq("audit"))
+	log.Log(audit, "audit")
+
+	log.SetLevel(notice)
+	log.Log(InfoLevel, "info")
+	log.Log(notice, "notice")
+	stdout.EXPECT().Log(gomock.Eq(audit), gomock.Eq("audit"))
+	log.Log(audit, "audit")
 }
 
 type testFramework struct {
@@ -409,6 +771,7 @@ var loggerLevels = map[int]Level{
 	4: ErrorLevel,
 	5: PanicLevel,
 	6: FatalLevel,
+	7: TraceLevel,
 }
 
 var levelFormats = map[int]LevelFormat{
@@ -546,6 +909,7 @@ func FuzzLogger(f *testing.F) {
 			return writers[path], nil
 		}).AnyTimes()
 
+		defer resetRootLogger()()
 		assert.NoError(t, configure(framework, config, func(path string) (io.Writer, error) {
 			return bytes.NewBuffer([]byte(path)), nil
 		}))
@@ -556,6 +920,9 @@ func FuzzLogger(f *testing.F) {
 				rootLevel = output.Level.Level()
 			}
 			writer := writers[outputName]
+			if rootLevel.Enabled(TraceLevel) {
+				writer.EXPECT().Trace(gomock.Eq("trace"))
+			}
 			if rootLevel.Enabled(DebugLevel) {
 				writer.EXPECT().Debug(gomock.Eq("debug"))
 			}
@@ -576,6 +943,7 @@ func FuzzLogger(f *testing.F) {
 			}
 		}
 
+		GetRootLogger().Trace("trace")
 		GetRootLogger().Debug("debug")
 		GetRootLogger().Info("info")
 		GetRootLogger().Warn("warn")
@@ -673,6 +1041,9 @@ func FuzzLogger(f *testing.F) {
 					}
 				}
 
+				if outputLevel.Enabled(TraceLevel) {
+					writer.EXPECT().Trace(gomock.Eq("trace"))
+				}
 				if outputLevel.Enabled(DebugLevel) {
 					writer.EXPECT().Debug(gomock.Eq("debug"))
 				}
@@ -692,6 +1063,7 @@ func FuzzLogger(f *testing.F) {
 					writer.EXPECT().Fatal(gomock.Eq("fatal"))
 				}
 			}
+			GetLogger(loggerName).Trace("trace")
 			GetLogger(loggerName).Debug("debug")
 			GetLogger(loggerName).Info("info")
 			GetLogger(loggerName).Warn("warn")
@@ -0,0 +1,190 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logr
+
+import (
+	"fmt"
+	"github.com/atomix/dazl"
+	"github.com/go-logr/logr"
+	"time"
+)
+
+// noValue is the value logged for a key with no matching value
+const noValue = "<no-value>"
+
+// NewLogger returns a logr.Logger that writes to the given dazl Logger
+func NewLogger(logger dazl.Logger) logr.Logger {
+	return logr.New(NewLogSink(logger))
+}
+
+// NewLogSink returns a logr.LogSink that writes to the given dazl Logger.
+// Logger names are mapped onto child loggers in the dazl logger tree, so entries are
+// filtered by the levels configured for the child loggers. V-level 0 is logged at the
+// info level, V-level 1 at the debug level, and higher V-levels at the trace level.
+func NewLogSink(logger dazl.Logger) logr.LogSink {
+	return &LogSink{
+		base:   logger,
+		logger: logger,
+	}
+}
+
+// LogSink is a logr.LogSink that writes to a dazl Logger
+type LogSink struct {
+	base      dazl.Logger
+	logger    dazl.Logger
+	values    []dazl.Field
+	callDepth int
+}
+
+func (s *LogSink) Init(info logr.RuntimeInfo) {
+	s.callDepth = info.CallDepth
+}
+
+func (s *LogSink) Enabled(level int) bool {
+	return s.logger.Level().Enabled(toLevel(level))
+}
+
+func (s *LogSink) Info(level int, msg string, keysAndValues ...any) {
+	// Skip the LogSink method and the logr.Logger frames
+	logger := s.logger.WithSkipCalls(s.callDepth + 1)
+	fields := appendFields(nil, keysAndValues)
+	switch toLevel(level) {
+	case dazl.InfoLevel:
+		logger.Infow(msg, fields...)
+	case dazl.DebugLevel:
+		logger.Debugw(msg, fields...)
+	default:
+		logger.Tracew(msg, fields...)
+	}
+}
+
+func (s *LogSink) Error(err error, msg string, keysAndValues ...any) {
+	fields := make([]dazl.Field, 0, len(keysAndValues)/2+1)
+	if err != nil {
+		fields = append(fields, dazl.Error(err))
+	}
+	fields = appendFields(fields, keysAndValues)
+	s.logger.WithSkipCalls(s.callDepth+1).Errorw(msg, fields...)
+}
+
+func (s *LogSink) WithValues(keysAndValues ...any) logr.LogSink {
+	fields := appendFields(nil, keysAndValues)
+	values := make([]dazl.Field, 0, len(s.values)+len(fields))
+	values = append(values, s.values...)
+	values = append(values, fields...)
+	return &LogSink{
+		base:      s.base,
+		logger:    s.logger.WithFields(fields...),
+		values:    values,
+		callDepth: s.callDepth,
+	}
+}
+
+func (s *LogSink) WithName(name string) logr.LogSink {
+	if name == "" {
+		return s
+	}
+	// Child loggers are created from the base logger to inherit the configuration of the
+	// logger tree, so the values are reapplied to the child logger.
+	base := s.base.GetLogger(name)
+	logger := base
+	if len(s.values) > 0 {
+		logger = base.WithFields(s.values...)
+	}
+	return &LogSink{
+		base:      base,
+		logger:    logger,
+		values:    s.values,
+		callDepth: s.callDepth,
+	}
+}
+
+func (s *LogSink) WithCallDepth(depth int) logr.LogSink {
+	return &LogSink{
+		base:      s.base,
+		logger:    s.logger,
+		values:    s.values,
+		callDepth: s.callDepth + depth,
+	}
+}
+
+var _ logr.LogSink = (*LogSink)(nil)
+var _ logr.CallDepthLogSink = (*LogSink)(nil)
+
+// toLevel maps a logr V-level to the dazl level at which it's logged
+func toLevel(level int) dazl.Level {
+	switch {
+	case level <= 0:
+		return dazl.InfoLevel
+	case level == 1:
+		return dazl.DebugLevel
+	default:
+		return dazl.TraceLevel
+	}
+}
+
+// appendFields converts the given key/value pairs to dazl fields, appending them to fields
+func appendFields(fields []dazl.Field, keysAndValues []any) []dazl.Field {
+	for i := 0; i < len(keysAndValues); i += 2 {
+		name, ok := keysAndValues[i].(string)
+		if !ok {
+			name = fmt.Sprint(keysAndValues[i])
+		}
+		if i+1 == len(keysAndValues) {
+			fields = append(fields, dazl.String(name, noValue))
+			break
+		}
+		fields = append(fields, toField(name, keysAndValues[i+1]))
+	}
+	return fields
+}
+
+// toField converts the given value to a dazl field
+func toField(name string, value any) dazl.Field {
+	switch v := value.(type) {
+	case string:
+		return dazl.String(name, v)
+	case bool:
+		return dazl.Bool(name, v)
+	case int:
+		return dazl.Int(name, v)
+	case int32:
+		return dazl.Int32(name, v)
+	case int64:
+		return dazl.Int64(name, v)
+	case uint:
+		return dazl.Uint(name, v)
+	case uint32:
+		return dazl.Uint32(name, v)
+	case uint64:
+		return dazl.Uint64(name, v)
+	case float32:
+		return dazl.Float32(name, v)
+	case float64:
+		return dazl.Float64(name, v)
+	case time.Time:
+		return dazl.Time(name, v)
+	case time.Duration:
+		return dazl.Duration(name, v)
+	case []string:
+		return dazl.Strings(name, v)
+	case []bool:
+		return dazl.Bools(name, v)
+	case []int:
+		return dazl.Ints(name, v)
+	case []int64:
+		return dazl.Int64s(name, v)
+	case []float64:
+		return dazl.Float64s(name, v)
+	case []byte:
+		return dazl.Bytes(name, v)
+	case error:
+		return dazl.String(name, v.Error())
+	case fmt.Stringer:
+		return dazl.Stringer(name, v)
+	default:
+		return dazl.String(name, fmt.Sprint(v))
+	}
+}
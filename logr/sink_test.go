@@ -0,0 +1,259 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logr
+
+import (
+	"errors"
+	"fmt"
+	"github.com/atomix/dazl"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+func TestLogSink(t *testing.T) {
+	entries := &[]string{}
+	root := &testLogger{
+		writer: &testWriter{entries: entries},
+		levels: map[string]dazl.Level{
+			"":    dazl.InfoLevel,
+			"foo": dazl.DebugLevel,
+			"bar": dazl.ErrorLevel,
+			"baz": dazl.TraceLevel,
+		},
+	}
+	log := NewLogger(root)
+
+	log.Info("Hello world!")
+	assert.Equal(t, "info sink_test.go:31 Hello world!", last(entries))
+
+	log.V(1).Info("Hello world!")
+	assert.Len(t, *entries, 1)
+
+	log.Info("Hello world!", "foo", "bar", "baz", 1, "odd")
+	assert.Equal(t, "info sink_test.go:37 Hello world! foo=bar baz=1 odd=<no-value>", last(entries))
+
+	log.Error(errors.New("bar"), "Hello world!", "foo", true)
+	assert.Equal(t, "error sink_test.go:40 Hello world! error=bar foo=true", last(entries))
+
+	foo := log.WithValues("a", 1).WithName("foo")
+	foo.V(1).Info("Hello world!", "b", 2)
+	assert.Equal(t, "debug foo sink_test.go:44 Hello world! a=1 b=2", last(entries))
+
+	foo.WithCallDepth(1).Info("Hello world!")
+	assert.Equal(t, fmt.Sprintf("info foo %s Hello world! a=1", caller(0)), last(entries))
+
+	count := len(*entries)
+	bar := log.WithName("bar")
+	assert.False(t, bar.Enabled())
+	bar.Info("Hello world!")
+	assert.Len(t, *entries, count)
+	bar.Error(nil, "Hello world!")
+	assert.Equal(t, "error bar sink_test.go:55 Hello world!", last(entries))
+
+	log.WithName("foo").WithName("baz").V(1).Info("Hello world!")
+	assert.Equal(t, "debug foo/baz sink_test.go:58 Hello world!", last(entries))
+
+	count = len(*entries)
+	log.WithName("foo").V(2).Info("Hello world!")
+	assert.Len(t, *entries, count)
+	log.WithName("baz").V(2).Info("Hello world!")
+	assert.Equal(t, "trace baz sink_test.go:64 Hello world!", last(entries))
+
+	assert.Same(t, foo.GetSink(), foo.WithName("").GetSink())
+	foo.WithName("").V(1).Info("Hello world!")
+	assert.Equal(t, "debug foo sink_test.go:68 Hello world! a=1", last(entries))
+}
+
+func last(entries *[]string) string {
+	if len(*entries) == 0 {
+		return ""
+	}
+	return (*entries)[len(*entries)-1]
+}
+
+// caller returns the file and line of the caller of the calling function, skipping the given number of frames
+func caller(skip int) string {
+	_, file, line, _ := runtime.Caller(2 + skip)
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
+
+// testLogger is a minimal dazl.Logger with a static level for each logger path
+type testLogger struct {
+	dazl.Logger
+	path   string
+	writer dazl.Writer
+	levels map[string]dazl.Level
+}
+
+func (l *testLogger) Level() dazl.Level {
+	path := l.path
+	for {
+		if level, ok := l.levels[path]; ok {
+			return level
+		}
+		i := strings.LastIndex(path, "/")
+		if i == -1 {
+			return l.levels[""]
+		}
+		path = path[:i]
+	}
+}
+
+func (l *testLogger) GetLogger(path string) dazl.Logger {
+	if l.path != "" {
+		path = l.path + "/" + path
+	}
+	return &testLogger{
+		path:   path,
+		writer: l.writer.WithName(path),
+		levels: l.levels,
+	}
+}
+
+func (l *testLogger) WithFields(fields ...dazl.Field) dazl.Logger {
+	writer := l.writer
+	for _, field := range fields {
+		var err error
+		if writer, err = field(writer); err != nil {
+			panic(err)
+		}
+	}
+	return &testLogger{
+		path:   l.path,
+		writer: writer,
+		levels: l.levels,
+	}
+}
+
+func (l *testLogger) WithSkipCalls(calls int) dazl.Logger {
+	return &testLogger{
+		path:   l.path,
+		writer: l.writer.WithSkipCalls(calls),
+		levels: l.levels,
+	}
+}
+
+func (l *testLogger) Tracew(msg string, fields ...dazl.Field) {
+	if l.Level().Enabled(dazl.TraceLevel) {
+		l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Trace(msg)
+	}
+}
+
+func (l *testLogger) Debugw(msg string, fields ...dazl.Field) {
+	if l.Level().Enabled(dazl.DebugLevel) {
+		l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Debug(msg)
+	}
+}
+
+func (l *testLogger) Infow(msg string, fields ...dazl.Field) {
+	if l.Level().Enabled(dazl.InfoLevel) {
+		l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Info(msg)
+	}
+}
+
+func (l *testLogger) Errorw(msg string, fields ...dazl.Field) {
+	if l.Level().Enabled(dazl.ErrorLevel) {
+		l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Error(msg)
+	}
+}
+
+// testWriter is a dazl.Writer that records entries as strings
+type testWriter struct {
+	entries   *[]string
+	name      string
+	fields    []string
+	skipCalls int
+}
+
+func (w *testWriter) WithName(name string) dazl.Writer {
+	return &testWriter{
+		entries:   w.entries,
+		name:      name,
+		fields:    w.fields,
+		skipCalls: w.skipCalls,
+	}
+}
+
+func (w *testWriter) WithSkipCalls(calls int) dazl.Writer {
+	return &testWriter{
+		entries:   w.entries,
+		name:      w.name,
+		fields:    w.fields,
+		skipCalls: w.skipCalls + calls,
+	}
+}
+
+func (w *testWriter) withField(name string, value any) dazl.Writer {
+	fields := make([]string, 0, len(w.fields)+1)
+	fields = append(fields, w.fields...)
+	fields = append(fields, fmt.Sprintf("%s=%v", name, value))
+	return &testWriter{
+		entries:   w.entries,
+		name:      w.name,
+		fields:    fields,
+		skipCalls: w.skipCalls,
+	}
+}
+
+func (w *testWriter) WithErrorField(err error) dazl.Writer {
+	return w.withField("error", err.Error())
+}
+
+func (w *testWriter) WithStringField(name string, value string) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) WithIntField(name string, value int) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) WithBoolField(name string, value bool) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *testWriter) Trace(msg string) {
+	w.log("trace", msg)
+}
+
+func (w *testWriter) Debug(msg string) {
+	w.log("debug", msg)
+}
+
+func (w *testWriter) Info(msg string) {
+	w.log("info", msg)
+}
+
+func (w *testWriter) Warn(msg string) {
+	w.log("warn", msg)
+}
+
+func (w *testWriter) Log(level dazl.Level, msg string) {
+	w.log(level.String(), msg)
+}
+
+func (w *testWriter) Error(msg string) {
+	w.log("error", msg)
+}
+
+func (w *testWriter) Fatal(msg string) {
+	w.log("fatal", msg)
+}
+
+func (w *testWriter) Panic(msg string) {
+	w.log("panic", msg)
+}
+
+func (w *testWriter) log(level string, msg string) {
+	elems := []string{level}
+	if w.name != "" {
+		elems = append(elems, w.name)
+	}
+	elems = append(elems, caller(w.skipCalls+1), msg)
+	elems = append(elems, w.fields...)
+	*w.entries = append(*w.entries, strings.Join(elems, " "))
+}
@@ -0,0 +1,344 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logrus
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/atomix/dazl"
+	"github.com/sirupsen/logrus"
+	"io"
+	"strings"
+)
+
+const (
+	defaultNameKey       = "logger"
+	defaultMessageKey    = "message"
+	defaultLevelKey      = "level"
+	defaultTimestampKey  = "time"
+	defaultCallerKey     = "caller"
+	defaultStacktraceKey = "trace"
+)
+
+// encoderConfig is the set of encoder options applied to logrus formatters.
+// An empty key indicates the field is disabled, or for the message and level
+// keys that the logrus default key is used.
+type encoderConfig struct {
+	nameKey         string
+	messageKey      string
+	levelKey        string
+	levelFormat     dazl.LevelFormat
+	timestampKey    string
+	timestampFormat dazl.TimestampFormat
+	callerKey       string
+	callerFormat    dazl.CallerFormat
+	stacktraceKey   string
+}
+
+// fieldMap returns the logrus keys for the built-in fields
+func (c encoderConfig) fieldMap() logrus.FieldMap {
+	fieldMap := logrus.FieldMap{}
+	if c.messageKey != "" {
+		fieldMap[logrus.FieldKeyMsg] = c.messageKey
+	}
+	if c.levelKey != "" {
+		fieldMap[logrus.FieldKeyLevel] = c.levelKey
+	}
+	if c.timestampKey != "" {
+		if c.timestampFormat == dazl.UnixTimestampFormat {
+			// logrus only formats timestamps with time layouts, so unix timestamps are written by the
+			// Writer as an ordinary field. Unmap the built-in key so logrus doesn't treat it as a clash.
+			fieldMap[logrus.FieldKeyTime] = ""
+		} else {
+			fieldMap[logrus.FieldKeyTime] = c.timestampKey
+		}
+	}
+	return fieldMap
+}
+
+// builtinTimestamp returns whether the timestamp is written by the logrus formatter
+func (c encoderConfig) builtinTimestamp() bool {
+	return c.timestampKey != "" && c.timestampFormat != dazl.UnixTimestampFormat
+}
+
+// formatter wraps a logrus formatter to apply the level format and custom level names, which logrus doesn't support.
+// logrus formatters always write the level, so the level is removed from the output when it's not enabled.
+type formatter struct {
+	logrus.Formatter
+	levelFormat  dazl.LevelFormat
+	levelPattern string
+	fieldSep     string
+}
+
+func (f *formatter) Format(entry *logrus.Entry) ([]byte, error) {
+	data, err := f.Formatter.Format(entry)
+	if err != nil {
+		return data, err
+	}
+	level := entry.Level.String()
+	if f.levelFormat == "" {
+		return removeField(data, fmt.Sprintf(f.levelPattern, level), f.fieldSep), nil
+	}
+	name := level
+	if custom, ok := customLevel(entry); ok {
+		name = custom.String()
+	}
+	switch f.levelFormat {
+	case dazl.UpperCaseLevelFormat:
+		name = strings.ToUpper(name)
+	case dazl.LowerCaseColorLevelFormat:
+		name = dazl.ColorLevel(dazlLevel(entry), name)
+	case dazl.UpperCaseColorLevelFormat:
+		name = dazl.ColorLevel(dazlLevel(entry), strings.ToUpper(name))
+	}
+	if name == level {
+		return data, nil
+	}
+	return bytes.Replace(data,
+		[]byte(fmt.Sprintf(f.levelPattern, level)),
+		[]byte(fmt.Sprintf(f.levelPattern, name)), 1), nil
+}
+
+// removeField removes the first occurrence of the given field and its separator from the formatted data
+func removeField(data []byte, field string, sep string) []byte {
+	for _, s := range []string{field + sep, sep + field, field} {
+		if i := bytes.Index(data, []byte(s)); i != -1 {
+			return append(data[:i:i], data[i+len(s):]...)
+		}
+	}
+	return data
+}
+
+// dazlLevel returns the dazl level of the given entry
+func dazlLevel(entry *logrus.Entry) dazl.Level {
+	if custom, ok := customLevel(entry); ok {
+		return custom
+	}
+	switch entry.Level {
+	case logrus.PanicLevel:
+		return dazl.PanicLevel
+	case logrus.FatalLevel:
+		return dazl.FatalLevel
+	case logrus.ErrorLevel:
+		return dazl.ErrorLevel
+	case logrus.WarnLevel:
+		return dazl.WarnLevel
+	case logrus.InfoLevel:
+		return dazl.InfoLevel
+	case logrus.DebugLevel:
+		return dazl.DebugLevel
+	default:
+		return dazl.TraceLevel
+	}
+}
+
+type logrusEncoder struct {
+	config  encoderConfig
+	newFunc func(encoderConfig) dazl.Encoder
+}
+
+func (e *logrusEncoder) with(f func(*encoderConfig)) dazl.Encoder {
+	config := e.config
+	f(&config)
+	return e.newFunc(config)
+}
+
+func (e *logrusEncoder) WithNameEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.nameKey = defaultNameKey
+	}), nil
+}
+
+func (e *logrusEncoder) WithLevelEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.levelFormat = dazl.LowerCaseLevelFormat
+	}), nil
+}
+
+func (e *logrusEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.LowerCaseLevelFormat, dazl.UpperCaseLevelFormat:
+		return e.with(func(config *encoderConfig) {
+			config.levelFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported level format '%s'", format)
+	}
+}
+
+func (e *logrusEncoder) WithTimestampEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.timestampKey = defaultTimestampKey
+		config.timestampFormat = dazl.ISO8601TimestampFormat
+	}), nil
+}
+
+func (e *logrusEncoder) WithTimestampFormat(format dazl.TimestampFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.ISO8601TimestampFormat, dazl.UnixTimestampFormat:
+		return e.with(func(config *encoderConfig) {
+			config.timestampFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported time format '%s'", format)
+	}
+}
+
+func (e *logrusEncoder) WithCallerEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.callerKey = defaultCallerKey
+		config.callerFormat = dazl.ShortCallerFormat
+	}), nil
+}
+
+func (e *logrusEncoder) WithCallerFormat(format dazl.CallerFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.ShortCallerFormat, dazl.FullCallerFormat:
+		return e.with(func(config *encoderConfig) {
+			config.callerFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported caller format '%s'", format)
+	}
+}
+
+func (e *logrusEncoder) WithStacktraceEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.stacktraceKey = defaultStacktraceKey
+	}), nil
+}
+
+func newConsoleEncoder(config encoderConfig) dazl.Encoder {
+	return &consoleEncoder{
+		logrusEncoder: &logrusEncoder{
+			config:  config,
+			newFunc: newConsoleEncoder,
+		},
+	}
+}
+
+type consoleEncoder struct {
+	*logrusEncoder
+}
+
+func (e *consoleEncoder) NewWriter(writer io.Writer) (dazl.Writer, error) {
+	levelKey := e.config.levelKey
+	if levelKey == "" {
+		levelKey = logrus.FieldKeyLevel
+	}
+	return newWriter(writer, &formatter{
+		Formatter: &logrus.TextFormatter{
+			DisableColors:    true,
+			DisableTimestamp: !e.config.builtinTimestamp(),
+			FullTimestamp:    true,
+			TimestampFormat:  dazl.ISO8601TimeLayout,
+			FieldMap:         e.config.fieldMap(),
+		},
+		levelFormat:  e.config.levelFormat,
+		levelPattern: levelKey + "=%s",
+		fieldSep:     " ",
+	}, e.config), nil
+}
+
+func (e *consoleEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.LowerCaseColorLevelFormat, dazl.UpperCaseColorLevelFormat:
+		return e.with(func(config *encoderConfig) {
+			config.levelFormat = format
+		}), nil
+	default:
+		return e.logrusEncoder.WithLevelFormat(format)
+	}
+}
+
+var _ dazl.NameEncoder = (*consoleEncoder)(nil)
+var _ dazl.LevelEncoder = (*consoleEncoder)(nil)
+var _ dazl.LevelFormattingEncoder = (*consoleEncoder)(nil)
+var _ dazl.TimestampEncoder = (*consoleEncoder)(nil)
+var _ dazl.TimestampFormattingEncoder = (*consoleEncoder)(nil)
+var _ dazl.CallerEncoder = (*consoleEncoder)(nil)
+var _ dazl.CallerFormattingEncoder = (*consoleEncoder)(nil)
+var _ dazl.StacktraceEncoder = (*consoleEncoder)(nil)
+
+func newJSONEncoder(config encoderConfig) dazl.Encoder {
+	return &jsonEncoder{
+		logrusEncoder: &logrusEncoder{
+			config:  config,
+			newFunc: newJSONEncoder,
+		},
+	}
+}
+
+type jsonEncoder struct {
+	*logrusEncoder
+}
+
+func (e *jsonEncoder) NewWriter(writer io.Writer) (dazl.Writer, error) {
+	levelKey := e.config.levelKey
+	if levelKey == "" {
+		levelKey = logrus.FieldKeyLevel
+	}
+	return newWriter(writer, &formatter{
+		Formatter: &logrus.JSONFormatter{
+			DisableTimestamp: !e.config.builtinTimestamp(),
+			TimestampFormat:  dazl.ISO8601TimeLayout,
+			FieldMap:         e.config.fieldMap(),
+		},
+		levelFormat:  e.config.levelFormat,
+		levelPattern: `"` + levelKey + `":"%s"`,
+		fieldSep:     ",",
+	}, e.config), nil
+}
+
+func (e *jsonEncoder) WithMessageKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.messageKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithNameKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.nameKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithLevelKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.levelKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithTimestampKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.timestampKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithCallerKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.callerKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithStacktraceKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.stacktraceKey = key
+	}), nil
+}
+
+var _ dazl.MessageKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.NameEncoder = (*jsonEncoder)(nil)
+var _ dazl.NameKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.LevelEncoder = (*jsonEncoder)(nil)
+var _ dazl.LevelKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.LevelFormattingEncoder = (*jsonEncoder)(nil)
+var _ dazl.TimestampEncoder = (*jsonEncoder)(nil)
+var _ dazl.TimestampKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.TimestampFormattingEncoder = (*jsonEncoder)(nil)
+var _ dazl.CallerEncoder = (*jsonEncoder)(nil)
+var _ dazl.CallerKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.CallerFormattingEncoder = (*jsonEncoder)(nil)
+var _ dazl.StacktraceEncoder = (*jsonEncoder)(nil)
+var _ dazl.StacktraceKeyEncoder = (*jsonEncoder)(nil)
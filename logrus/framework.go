@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logrus
+
+import (
+	"github.com/atomix/dazl"
+)
+
+func init() {
+	dazl.Register(&Framework{})
+}
+
+type Framework struct{}
+
+func (f *Framework) Name() string {
+	return "logrus"
+}
+
+func (f *Framework) ConsoleEncoder() dazl.Encoder {
+	return newConsoleEncoder(encoderConfig{})
+}
+
+func (f *Framework) JSONEncoder() dazl.Encoder {
+	return newJSONEncoder(encoderConfig{
+		messageKey: defaultMessageKey,
+	})
+}
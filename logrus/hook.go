@@ -0,0 +1,44 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logrus
+
+import (
+	"github.com/sirupsen/logrus"
+	"sync"
+)
+
+var hooks = &hookRegistry{
+	hooks: make(logrus.LevelHooks),
+}
+
+// AddHook adds a logrus hook to be fired for entries written by all dazl logrus writers.
+// Hooks may be added at any time, including after the loggers have been configured.
+func AddHook(hook logrus.Hook) {
+	hooks.add(hook)
+}
+
+// hookRegistry is a logrus hook that fires the hooks added with AddHook
+type hookRegistry struct {
+	hooks logrus.LevelHooks
+	mu    sync.RWMutex
+}
+
+func (r *hookRegistry) add(hook logrus.Hook) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.hooks.Add(hook)
+}
+
+func (r *hookRegistry) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *hookRegistry) Fire(entry *logrus.Entry) error {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.hooks.Fire(entry.Level, entry)
+}
+
+var _ logrus.Hook = (*hookRegistry)(nil)
@@ -0,0 +1,46 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logrus
+
+import (
+	"bytes"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type testHook struct {
+	entries []*logrus.Entry
+}
+
+func (h *testHook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.WarnLevel, logrus.ErrorLevel}
+}
+
+func (h *testHook) Fire(entry *logrus.Entry) error {
+	h.entries = append(h.entries, entry)
+	return nil
+}
+
+func TestAddHook(t *testing.T) {
+	buf := &bytes.Buffer{}
+	writer, err := (&Framework{}).JSONEncoder().NewWriter(buf)
+	assert.NoError(t, err)
+
+	hook := &testHook{}
+	AddHook(hook)
+	defer func() {
+		hooks.hooks = make(logrus.LevelHooks)
+	}()
+
+	writer.Info("Hello world!")
+	assert.Len(t, hook.entries, 0)
+	writer.(*Writer).WithStringField("foo", "bar").Warn("Hello world!")
+	assert.Len(t, hook.entries, 1)
+	assert.Equal(t, "Hello world!", hook.entries[0].Message)
+	assert.Equal(t, "bar", hook.entries[0].Data["foo"])
+	writer.Error("Hello world!")
+	assert.Len(t, hook.entries, 2)
+}
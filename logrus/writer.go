@@ -0,0 +1,281 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logrus
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/atomix/dazl"
+	"github.com/sirupsen/logrus"
+	"io"
+	"runtime"
+	"time"
+)
+
+func newWriter(out io.Writer, formatter logrus.Formatter, config encoderConfig) dazl.Writer {
+	logger := logrus.New()
+	logger.SetOutput(out)
+	logger.SetFormatter(formatter)
+	logger.SetLevel(logrus.TraceLevel)
+	logger.AddHook(hooks)
+	entry := logrus.NewEntry(logger)
+	return &Writer{
+		root:   entry,
+		entry:  entry,
+		config: config,
+	}
+}
+
+// Writer is a dazl output implementation
+type Writer struct {
+	root      *logrus.Entry
+	entry     *logrus.Entry
+	config    encoderConfig
+	skipCalls int
+}
+
+func (w *Writer) WithName(name string) dazl.Writer {
+	if w.config.nameKey == "" {
+		return w
+	}
+	return &Writer{
+		root:      w.root,
+		entry:     w.root.WithField(w.config.nameKey, name),
+		config:    w.config,
+		skipCalls: w.skipCalls,
+	}
+}
+
+func (w *Writer) WithSkipCalls(calls int) dazl.Writer {
+	return &Writer{
+		root:      w.root,
+		entry:     w.entry,
+		config:    w.config,
+		skipCalls: w.skipCalls + calls,
+	}
+}
+
+func (w *Writer) withField(name string, value any) dazl.Writer {
+	return &Writer{
+		root:      w.root,
+		entry:     w.entry.WithField(name, value),
+		config:    w.config,
+		skipCalls: w.skipCalls,
+	}
+}
+
+func (w *Writer) WithErrorField(err error) dazl.Writer {
+	return w.withField(logrus.ErrorKey, err)
+}
+
+func (w *Writer) WithStringField(name string, value string) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithBoolField(name string, value bool) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithIntField(name string, value int) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithInt32Field(name string, value int32) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithInt64Field(name string, value int64) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithUintField(name string, value uint) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithUint32Field(name string, value uint32) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithUint64Field(name string, value uint64) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithFloat32Field(name string, value float32) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithFloat64Field(name string, value float64) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithTimeField(name string, value time.Time) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithDurationField(name string, value time.Duration) dazl.Writer {
+	return w.withField(name, value)
+}
+
+func (w *Writer) WithBinaryField(name string, value []byte) dazl.Writer {
+	return w.withField(name, base64.StdEncoding.EncodeToString(value))
+}
+
+func (w *Writer) WithBytesField(name string, value []byte) dazl.Writer {
+	return w.withField(name, string(value))
+}
+
+func (w *Writer) WithStringSliceField(name string, values []string) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithBoolSliceField(name string, values []bool) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithIntSliceField(name string, values []int) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithInt32SliceField(name string, values []int32) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithInt64SliceField(name string, values []int64) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithUintSliceField(name string, values []uint) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithUint32SliceField(name string, values []uint32) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithUint64SliceField(name string, values []uint64) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithFloat32SliceField(name string, values []float32) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithFloat64SliceField(name string, values []float64) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithTimeSliceField(name string, values []time.Time) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) WithDurationSliceField(name string, values []time.Duration) dazl.Writer {
+	return w.withField(name, values)
+}
+
+func (w *Writer) Trace(msg string) {
+	w.log(logrus.TraceLevel, msg)
+}
+
+func (w *Writer) Debug(msg string) {
+	w.log(logrus.DebugLevel, msg)
+}
+
+func (w *Writer) Info(msg string) {
+	w.log(logrus.InfoLevel, msg)
+}
+
+func (w *Writer) Error(msg string) {
+	w.log(logrus.ErrorLevel, msg)
+}
+
+func (w *Writer) Fatal(msg string) {
+	w.log(logrus.FatalLevel, msg)
+	w.entry.Logger.Exit(1)
+}
+
+func (w *Writer) Panic(msg string) {
+	// logrus panics with the entry after writing entries at the panic level, so panic with the message instead
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(*logrus.Entry); ok {
+				panic(msg)
+			}
+			panic(r)
+		}
+	}()
+	w.log(logrus.PanicLevel, msg)
+}
+
+func (w *Writer) Warn(msg string) {
+	w.log(logrus.WarnLevel, msg)
+}
+
+// customLevelKey is the context key of the custom level of an entry
+type customLevelKey struct{}
+
+func (w *Writer) Log(level dazl.Level, msg string) {
+	// logrus has no custom levels, so entries are written at the nearest logrus level below the custom level
+	// and the custom level is carried in the entry context for the formatter to write the custom level name
+	ctx := w.entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	writer := &Writer{
+		root:      w.root,
+		entry:     w.entry.WithContext(context.WithValue(ctx, customLevelKey{}, level)),
+		config:    w.config,
+		skipCalls: w.skipCalls,
+	}
+	writer.log(logrusLevel(level), msg)
+}
+
+// logrusLevel returns the logrus level at which entries at the given custom level are written. Levels above
+// the error level are written at the error level, since logrus panics and exits at the panic and fatal levels.
+func logrusLevel(level dazl.Level) logrus.Level {
+	switch {
+	case dazl.ErrorLevel.Enabled(level):
+		return logrus.ErrorLevel
+	case dazl.WarnLevel.Enabled(level):
+		return logrus.WarnLevel
+	case dazl.InfoLevel.Enabled(level):
+		return logrus.InfoLevel
+	case dazl.DebugLevel.Enabled(level):
+		return logrus.DebugLevel
+	default:
+		return logrus.TraceLevel
+	}
+}
+
+// customLevel returns the custom level of the given entry, if any
+func customLevel(entry *logrus.Entry) (dazl.Level, bool) {
+	if entry.Context == nil {
+		return dazl.EmptyLevel, false
+	}
+	level, ok := entry.Context.Value(customLevelKey{}).(dazl.Level)
+	return level, ok
+}
+
+// log writes an entry to the logger, attributing it to the caller of the Writer method
+func (w *Writer) log(level logrus.Level, msg string) {
+	entry := w.entry
+	if w.config.timestampKey != "" && w.config.timestampFormat == dazl.UnixTimestampFormat {
+		now := time.Now()
+		entry = entry.WithTime(now).WithField(w.config.timestampKey, float64(now.UnixNano())/float64(time.Second))
+	}
+	if w.config.callerKey != "" {
+		// Skip log and the Writer method
+		if _, file, line, ok := runtime.Caller(2 + w.skipCalls); ok {
+			entry = entry.WithField(w.config.callerKey, dazl.FormatCaller(file, line, w.config.callerFormat))
+		}
+	}
+	if w.config.stacktraceKey != "" && level <= logrus.ErrorLevel {
+		entry = entry.WithField(w.config.stacktraceKey, dazl.Stacktrace(4+w.skipCalls))
+	}
+	entry.Log(level, msg)
+}
+
+var _ dazl.Writer = (*Writer)(nil)
+var _ dazl.FieldWriter = (*Writer)(nil)
+var _ dazl.ErrorFieldWriter = (*Writer)(nil)
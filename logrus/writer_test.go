@@ -0,0 +1,152 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package logrus
+
+import (
+	"bytes"
+	"errors"
+	"github.com/atomix/dazl"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestWriter(t *testing.T) {
+	config := encoderConfig{
+		messageKey:  "message",
+		levelFormat: dazl.LowerCaseLevelFormat,
+	}
+
+	buf := &bytes.Buffer{}
+	writer, err := newJSONEncoder(config).NewWriter(buf)
+	assert.NoError(t, err)
+
+	writer.Trace("Hello world!")
+	assert.Equal(t, "{\"level\":\"trace\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Debug("Hello world!")
+	assert.Equal(t, "{\"level\":\"debug\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Warn("Hello world!")
+	assert.Equal(t, "{\"level\":\"warning\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.Error("Hello world!")
+	assert.Equal(t, "{\"level\":\"error\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	assert.NoError(t, dazl.RegisterLevel("audit", 45))
+	writer.Log(45, "Hello world!")
+	assert.Equal(t, "{\"level\":\"audit\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	assert.PanicsWithValue(t, "Hello world!", func() {
+		writer.Panic("Hello world!")
+	})
+	assert.Equal(t, "{\"level\":\"panic\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.ErrorFieldWriter).WithErrorField(errors.New("bar")).Info("Hello world!")
+	assert.Equal(t, "{\"error\":\"bar\",\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.StringFieldWriter).WithStringField("foo", "bar").Info("Hello world!")
+	assert.Equal(t, "{\"foo\":\"bar\",\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.IntFieldWriter).WithIntField("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Int32FieldWriter).WithInt32Field("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Int64FieldWriter).WithInt64Field("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.UintFieldWriter).WithUintField("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Uint32FieldWriter).WithUint32Field("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Uint64FieldWriter).WithUint64Field("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Float32FieldWriter).WithFloat32Field("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Float64FieldWriter).WithFloat64Field("foo", 1).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.BoolFieldWriter).WithBoolField("foo", true).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":true,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.DurationFieldWriter).WithDurationField("foo", time.Second).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":1000000000,\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.BinaryFieldWriter).WithBinaryField("foo", []byte("bar")).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":\"YmFy\",\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.BytesFieldWriter).WithBytesField("foo", []byte("bar")).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":\"bar\",\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.StringSliceFieldWriter).WithStringSliceField("foo", []string{"bar"}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[\"bar\"],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.IntSliceFieldWriter).WithIntSliceField("foo", []int{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Int32SliceFieldWriter).WithInt32SliceField("foo", []int32{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Int64SliceFieldWriter).WithInt64SliceField("foo", []int64{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.UintSliceFieldWriter).WithUintSliceField("foo", []uint{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Uint32SliceFieldWriter).WithUint32SliceField("foo", []uint32{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Uint64SliceFieldWriter).WithUint64SliceField("foo", []uint64{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Float32SliceFieldWriter).WithFloat32SliceField("foo", []float32{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.Float64SliceFieldWriter).WithFloat64SliceField("foo", []float64{1}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[1],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer.(dazl.BoolSliceFieldWriter).WithBoolSliceField("foo", []bool{true}).Info("Hello world!")
+	assert.Equal(t, "{\"foo\":[true],\"level\":\"info\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+}
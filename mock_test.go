@@ -331,6 +331,18 @@ func (mr *MockWriterMockRecorder) Info(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Info", reflect.TypeOf((*MockWriter)(nil).Info), arg0)
 }
 
+// Log mocks base method.
+func (m *MockWriter) Log(arg0 Level, arg1 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Log", arg0, arg1)
+}
+
+// Log indicates an expected call of Log.
+func (mr *MockWriterMockRecorder) Log(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Log", reflect.TypeOf((*MockWriter)(nil).Log), arg0, arg1)
+}
+
 // Panic mocks base method.
 func (m *MockWriter) Panic(arg0 string) {
 	m.ctrl.T.Helper()
@@ -343,6 +355,18 @@ func (mr *MockWriterMockRecorder) Panic(arg0 interface{}) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Panic", reflect.TypeOf((*MockWriter)(nil).Panic), arg0)
 }
 
+// Trace mocks base method.
+func (m *MockWriter) Trace(arg0 string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Trace", arg0)
+}
+
+// Trace indicates an expected call of Trace.
+func (mr *MockWriterMockRecorder) Trace(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Trace", reflect.TypeOf((*MockWriter)(nil).Trace), arg0)
+}
+
 // Warn mocks base method.
 func (m *MockWriter) Warn(arg0 string) {
 	m.ctrl.T.Helper()
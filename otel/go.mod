@@ -0,0 +1,20 @@
+module github.com/atomix/dazl/otel
+
+go 1.19
+
+require (
+	github.com/atomix/dazl v1.1.2
+	github.com/stretchr/testify v1.8.3
+	go.opentelemetry.io/otel/trace v1.16.0
+)
+
+require (
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/mitchellh/go-homedir v1.1.0 // indirect
+	github.com/pelletier/go-toml/v2 v2.0.5 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	go.opentelemetry.io/otel v1.16.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/atomix/dazl => ../
@@ -0,0 +1,32 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package otel
+
+import (
+	"context"
+	"github.com/atomix/dazl"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	dazl.RegisterSpanContextProvider(&SpanContextProvider{})
+}
+
+// SpanContextProvider is a dazl.SpanContextProvider that reads OpenTelemetry spans from a context
+type SpanContextProvider struct{}
+
+func (p *SpanContextProvider) SpanContextFromContext(ctx context.Context) (dazl.SpanContext, bool) {
+	spanContext := trace.SpanContextFromContext(ctx)
+	if !spanContext.IsValid() {
+		return dazl.SpanContext{}, false
+	}
+	return dazl.SpanContext{
+		TraceID:    spanContext.TraceID().String(),
+		SpanID:     spanContext.SpanID().String(),
+		TraceFlags: spanContext.TraceFlags().String(),
+	}, true
+}
+
+var _ dazl.SpanContextProvider = (*SpanContextProvider)(nil)
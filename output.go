@@ -90,14 +90,16 @@ type dazlOutput struct {
 	writer  Writer
 	level   Level
 	sampler Sampler
+	// levelSet and samplerSet indicate whether the level and sampler are set for the output's logger
+	// rather than inherited from the output of the logger's parent
+	levelSet   bool
+	samplerSet bool
 }
 
 func (o *dazlOutput) WithWriter(writer Writer) *dazlOutput {
-	return &dazlOutput{
-		writer:  writer,
-		level:   o.level,
-		sampler: o.sampler,
-	}
+	output := *o
+	output.writer = writer
+	return &output
 }
 
 func (o *dazlOutput) Level() Level {
@@ -105,53 +107,209 @@ func (o *dazlOutput) Level() Level {
 }
 
 func (o *dazlOutput) WithLevel(level Level) *dazlOutput {
-	return &dazlOutput{
-		writer:  o.writer,
-		level:   level,
-		sampler: o.sampler,
-	}
+	output := *o
+	output.level = level
+	output.levelSet = true
+	return &output
 }
 
 func (o *dazlOutput) WithSampler(sampler Sampler) *dazlOutput {
-	return &dazlOutput{
-		writer:  o.writer,
-		level:   o.level,
-		sampler: sampler,
+	output := *o
+	output.sampler = sampler
+	output.samplerSet = true
+	return &output
+}
+
+// inherit returns a copy of the output with the level and sampler of the given output of the logger's parent
+// where they are not set for the output's logger. If the parent has no output, nothing is inherited.
+func (o *dazlOutput) inherit(parent *dazlOutput) *dazlOutput {
+	output := *o
+	if !o.levelSet {
+		output.level = EmptyLevel
+		if parent != nil {
+			output.level = parent.level
+		}
+	}
+	if !o.samplerSet {
+		output.sampler = &allSampler{}
+		if parent != nil {
+			output.sampler = parent.sampler
+		}
+	}
+	return &output
+}
+
+// enabled returns whether the output level enables the given level. Outputs without a level write the entries
+// enabled by their logger's level, including trace entries.
+func (o *dazlOutput) enabled(level Level) bool {
+	return o.level == EmptyLevel || o.level.Enabled(level)
+}
+
+func (o *dazlOutput) Trace(msg string) {
+	if o.enabled(TraceLevel) && o.sampler.Sample(TraceLevel) {
+		o.writer.Trace(msg)
 	}
 }
 
 func (o *dazlOutput) Debug(msg string) {
-	if o.level.Enabled(DebugLevel) && o.sampler.Sample(DebugLevel) {
+	if o.enabled(DebugLevel) && o.sampler.Sample(DebugLevel) {
 		o.writer.Debug(msg)
 	}
 }
 
 func (o *dazlOutput) Info(msg string) {
-	if o.level.Enabled(InfoLevel) && o.sampler.Sample(InfoLevel) {
+	if o.enabled(InfoLevel) && o.sampler.Sample(InfoLevel) {
 		o.writer.Info(msg)
 	}
 }
 
 func (o *dazlOutput) Warn(msg string) {
-	if o.level.Enabled(WarnLevel) && o.sampler.Sample(WarnLevel) {
+	if o.enabled(WarnLevel) && o.sampler.Sample(WarnLevel) {
 		o.writer.Warn(msg)
 	}
 }
 
 func (o *dazlOutput) Error(msg string) {
-	if o.level.Enabled(ErrorLevel) && o.sampler.Sample(ErrorLevel) {
+	if o.enabled(ErrorLevel) && o.sampler.Sample(ErrorLevel) {
 		o.writer.Error(msg)
 	}
 }
 
 func (o *dazlOutput) Fatal(msg string) {
-	if o.level.Enabled(FatalLevel) && o.sampler.Sample(FatalLevel) {
+	if o.enabled(FatalLevel) && o.sampler.Sample(FatalLevel) {
 		o.writer.Fatal(msg)
 	}
 }
 
 func (o *dazlOutput) Panic(msg string) {
-	if o.level.Enabled(PanicLevel) && o.sampler.Sample(PanicLevel) {
+	if o.enabled(PanicLevel) && o.sampler.Sample(PanicLevel) {
+		o.writer.Panic(msg)
+	}
+}
+
+// Log writes an entry at the given level, writing entries at unfiltered custom levels regardless of the
+// output level and sampling
+func (o *dazlOutput) Log(level Level, msg string) {
+	if !level.unfiltered() && !(o.enabled(level) && o.sampler.Sample(level)) {
+		return
+	}
+	switch level {
+	case TraceLevel:
+		o.writer.Trace(msg)
+	case DebugLevel:
+		o.writer.Debug(msg)
+	case InfoLevel:
+		o.writer.Info(msg)
+	case WarnLevel:
+		o.writer.Warn(msg)
+	case ErrorLevel:
+		o.writer.Error(msg)
+	case PanicLevel:
 		o.writer.Panic(msg)
+	case FatalLevel:
+		o.writer.Fatal(msg)
+	default:
+		o.writer.Log(level, msg)
 	}
 }
+
+// Output is the output of a logger to a named writer
+type Output interface {
+	// Name returns the name of the writer
+	Name() string
+
+	// Level returns the output level. If empty, all entries written by the logger are written to the output.
+	Level() Level
+
+	// SetLevel sets the output level. The outputs of descendant loggers that don't set their own level for the
+	// writer inherit the level. If the level is empty, the output inherits the level of the parent's output.
+	SetLevel(level Level) error
+
+	// SetSampler sets the sampling of entries written to the output. The outputs of descendant loggers that don't
+	// configure their own sampling for the writer inherit the sampling. If the configuration is nil, the output
+	// inherits the sampling of the parent's output. Sampling applied by the writer itself is not changed.
+	SetSampler(config *SamplerConfig) error
+}
+
+// loggerOutput is the Output of a logger to a named writer
+type loggerOutput struct {
+	logger *loggerContext
+	name   string
+}
+
+func (o *loggerOutput) Name() string {
+	return o.name
+}
+
+func (o *loggerOutput) Level() Level {
+	if output, ok := o.logger.getState().outputs[o.name]; ok {
+		return output.level
+	}
+	return EmptyLevel
+}
+
+func (o *loggerOutput) SetLevel(level Level) error {
+	return o.logger.updateOutput(o.name, func(output *dazlOutput) *dazlOutput {
+		if level == EmptyLevel {
+			inherited := *output
+			inherited.levelSet = false
+			return &inherited
+		}
+		return output.WithLevel(level)
+	})
+}
+
+func (o *loggerOutput) SetSampler(config *SamplerConfig) error {
+	sampling, err := config.samplingConfig()
+	if err != nil {
+		return err
+	}
+	if (sampling.Basic != nil && sampling.Basic.Interval <= 0) || (sampling.Random != nil && sampling.Random.Interval <= 0) {
+		return fmt.Errorf("sampling interval must be a positive integer")
+	}
+	sampler := sampling.newSampler()
+	return o.logger.updateOutput(o.name, func(output *dazlOutput) *dazlOutput {
+		if sampler == nil {
+			inherited := *output
+			inherited.samplerSet = false
+			return &inherited
+		}
+		return output.WithSampler(sampler)
+	})
+}
+
+// updateOutput replaces the named output of the logger with the output returned by the given function,
+// inheriting the level and sampler of the parent's output where they are not set for the logger
+func (c *loggerContext) updateOutput(name string, update func(*dazlOutput) *dazlOutput) error {
+	loggersMu.Lock()
+	defer loggersMu.Unlock()
+	output, ok := c.getState().outputs[name]
+	if !ok {
+		return fmt.Errorf("logger '%s' has no output '%s'", c.name, name)
+	}
+	var parent *dazlOutput
+	if c.parent != nil {
+		parent = c.parent.getState().outputs[name]
+	}
+	c.setOutput(name, update(output).inherit(parent))
+	return nil
+}
+
+// setOutput replaces the named output of the logger and updates the outputs of its descendants, which inherit
+// the level and sampler of the output where they are not set for the descendants. loggersMu must be held.
+func (c *loggerContext) setOutput(name string, output *dazlOutput) {
+	state := *c.getState()
+	state.outputs = make(map[string]*dazlOutput, len(state.outputs))
+	for outputName, existing := range c.getState().outputs {
+		state.outputs[outputName] = existing
+	}
+	state.outputs[name] = output
+	c.state.Store(&state)
+	c.children.Range(func(key, value any) bool {
+		child := value.(*dazlLogger)
+		if childOutput, ok := child.getState().outputs[name]; ok {
+			child.setOutput(name, childOutput.inherit(output))
+		}
+		return true
+	})
+}
@@ -0,0 +1,140 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// regexPrefix is the prefix of logger keys that are regular expressions
+const regexPrefix = "re:"
+
+// globSegment matches any number of logger path segments in a glob pattern
+const globSegment = "**"
+
+// isLoggerPattern returns whether the given logger key is a pattern rather than a logger path
+func isLoggerPattern(key string) bool {
+	return strings.HasPrefix(key, regexPrefix) || strings.ContainsAny(key, "*?[")
+}
+
+// loggerPattern is a pattern matching the paths of loggers configured by a logger key. Keys prefixed with "re:"
+// are regular expressions matching the whole logger path. All other patterns are globs matched against each path
+// segment, where "*" matches any part of a segment and a "**" segment matches any number of segments.
+type loggerPattern struct {
+	key      string
+	regex    *regexp.Regexp
+	segments []string
+}
+
+func newLoggerPattern(key string) (*loggerPattern, error) {
+	if strings.HasPrefix(key, regexPrefix) {
+		regex, err := regexp.Compile(fmt.Sprintf("^(?:%s)$", strings.TrimPrefix(key, regexPrefix)))
+		if err != nil {
+			return nil, fmt.Errorf("invalid logger pattern '%s': %w", key, err)
+		}
+		return &loggerPattern{key: key, regex: regex}, nil
+	}
+	segments := strings.Split(strings.Trim(key, pathSep), pathSep)
+	for _, segment := range segments {
+		if _, err := path.Match(segment, ""); err != nil {
+			return nil, fmt.Errorf("invalid logger pattern '%s': %w", key, err)
+		}
+	}
+	return &loggerPattern{key: key, segments: segments}, nil
+}
+
+// match returns whether the pattern matches the logger with the given path
+func (p *loggerPattern) match(name string) bool {
+	if p.regex != nil {
+		return p.regex.MatchString(name)
+	}
+	return matchSegments(p.segments, strings.Split(name, pathSep))
+}
+
+func matchSegments(patterns, segments []string) bool {
+	if len(patterns) == 0 {
+		return len(segments) == 0
+	}
+	if patterns[0] == globSegment {
+		for i := 0; i <= len(segments); i++ {
+			if matchSegments(patterns[1:], segments[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(segments) == 0 {
+		return false
+	}
+	if ok, _ := path.Match(patterns[0], segments[0]); !ok {
+		return false
+	}
+	return matchSegments(patterns[1:], segments[1:])
+}
+
+// specificity returns the specificity of a glob pattern as the number of literal segments, the number of
+// segments matching exactly one segment, and the number of literal characters
+func (p *loggerPattern) specificity() (int, int, int) {
+	var literals, singles, chars int
+	for _, segment := range p.segments {
+		if segment != globSegment {
+			singles++
+		}
+		if !strings.ContainsAny(segment, "*?[") {
+			literals++
+		}
+		chars += len(segment) - strings.Count(segment, "*") - strings.Count(segment, "?")
+	}
+	return literals, singles, chars
+}
+
+// moreSpecific returns whether the pattern takes precedence over the given pattern. Globs take precedence over
+// regular expressions. Globs with more literal segments take precedence, then globs with more segments matching
+// exactly one segment, then globs with more literal characters. Longer regular expressions take precedence
+// over shorter ones, and ties are broken by the order of the keys.
+func (p *loggerPattern) moreSpecific(q *loggerPattern) bool {
+	if (p.regex == nil) != (q.regex == nil) {
+		return p.regex == nil
+	}
+	if p.regex == nil {
+		pLiterals, pSingles, pChars := p.specificity()
+		qLiterals, qSingles, qChars := q.specificity()
+		if pLiterals != qLiterals {
+			return pLiterals > qLiterals
+		}
+		if pSingles != qSingles {
+			return pSingles > qSingles
+		}
+		if pChars != qChars {
+			return pChars > qChars
+		}
+	} else if len(p.key) != len(q.key) {
+		return len(p.key) > len(q.key)
+	}
+	return p.key < q.key
+}
+
+// newLoggerPatterns returns the patterns of the given logger keys in order of decreasing precedence
+func newLoggerPatterns(loggers map[string]loggerConfig) ([]*loggerPattern, error) {
+	var patterns []*loggerPattern
+	for key := range loggers {
+		if !isLoggerPattern(key) {
+			continue
+		}
+		pattern, err := newLoggerPattern(key)
+		if err != nil {
+			return nil, err
+		}
+		patterns = append(patterns, pattern)
+	}
+	sort.Slice(patterns, func(i, j int) bool {
+		return patterns[i].moreSpecific(patterns[j])
+	})
+	return patterns, nil
+}
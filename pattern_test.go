@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoggerPatternMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		match   bool
+	}{
+		{"github.com/atomix/*/driver", "github.com/atomix/raft/driver", true},
+		{"github.com/atomix/*/driver", "github.com/atomix/driver", false},
+		{"github.com/atomix/*/driver", "github.com/atomix/raft/v2/driver", false},
+		{"github.com/atomix/*/driver", "github.com/atomix/raft/driver/client", false},
+		{"github.com/atomix/*-driver", "github.com/atomix/raft-driver", true},
+		{"github.com/acme/**", "github.com/acme", true},
+		{"github.com/acme/**", "github.com/acme/foo/bar", true},
+		{"github.com/acme/**", "github.com/acmeco/foo", false},
+		{"github.com/**/driver", "github.com/driver", true},
+		{"github.com/**/driver", "github.com/acme/foo/driver", true},
+		{"github.com/**/driver", "github.com/acme/foo/driver/client", false},
+		{"re:github\\.com/atomix/.*/v[0-9]+", "github.com/atomix/raft/v2", true},
+		{"re:github\\.com/atomix/.*/v[0-9]+", "github.com/atomix/raft/v2/client", false},
+	}
+	for _, test := range tests {
+		pattern, err := newLoggerPattern(test.pattern)
+		assert.NoError(t, err)
+		assert.Equal(t, test.match, pattern.match(test.name), "%s matching %s", test.pattern, test.name)
+	}
+
+	_, err := newLoggerPattern("re:github.com/(atomix")
+	assert.Error(t, err)
+	_, err = newLoggerPattern("github.com/[atomix")
+	assert.Error(t, err)
+}
+
+func TestLoggerPatternPrecedence(t *testing.T) {
+	patterns, err := newLoggerPatterns(map[string]loggerConfig{
+		"re:github\\.com/.*":             {},
+		"re:github\\.com/atomix/.*":      {},
+		"github.com/**":                  {},
+		"github.com/atomix/**":           {},
+		"github.com/atomix/*":            {},
+		"github.com/atomix/*/driver":     {},
+		"github.com/atomix/raft-*":       {},
+		"github.com/atomix/raft/driver":  {},
+		"github.com/atomix/**/driver/**": {},
+	})
+	assert.NoError(t, err)
+	var keys []string
+	for _, pattern := range patterns {
+		keys = append(keys, pattern.key)
+	}
+	assert.Equal(t, []string{
+		"github.com/atomix/*/driver",
+		"github.com/atomix/**/driver/**",
+		"github.com/atomix/raft-*",
+		"github.com/atomix/*",
+		"github.com/atomix/**",
+		"github.com/**",
+		"re:github\\.com/atomix/.*",
+		"re:github\\.com/.*",
+	}, keys)
+}
+
+const testPatternConfig = `
+encoders:
+  json:
+    fields:
+      - message
+      - name
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+loggers:
+  github.com/atomix/*/driver:
+    level: error
+  github.com/acme/**:
+    level: warn
+    sample:
+      basic:
+        interval: 2
+  github.com/acme/foo:
+    level: debug
+  re:github\.com/atomix/.*/v[0-9]+:
+    level: debug
+`
+
+func TestLoggerPatterns(t *testing.T) {
+	defer resetRootLogger()()
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testPatternConfig), &config))
+	buf := configureBuffer(t, config)
+
+	assert.Equal(t, ErrorLevel, GetLogger("github.com/atomix/raft/driver").Level())
+	assert.Equal(t, ErrorLevel, GetLogger("github.com/atomix/raft/driver/client").Level())
+	assert.Equal(t, InfoLevel, GetLogger("github.com/atomix/raft").Level())
+	assert.Equal(t, DebugLevel, GetLogger("github.com/atomix/raft/v2").Level())
+	assert.Equal(t, WarnLevel, GetLogger("github.com/acme").Level())
+	assert.Equal(t, WarnLevel, GetLogger("github.com/acme/bar/baz").Level())
+	assert.Equal(t, DebugLevel, GetLogger("github.com/acme/foo").Level())
+	assert.Equal(t, DebugLevel, GetLogger("github.com/acme/foo/bar").Level())
+
+	// The pattern is applied to the topmost matching logger, so sampling isn't compounded in descendants
+	log := GetLogger("github.com/acme/bar/baz")
+	for i := 0; i < 4; i++ {
+		log.Warn("Hello world!")
+	}
+	assert.Equal(t, "{\"logger\":\"github.com/acme/bar/baz\",\"message\":\"Hello world!\"}\n"+
+		"{\"logger\":\"github.com/acme/bar/baz\",\"message\":\"Hello world!\"}\n", buf.String())
+
+	explanation := explain(config.withPatterns(t), "github.com/acme/foo/bar")
+	assert.Equal(t, DebugLevel, explanation.Level)
+	assert.Equal(t, "loggers/github.com/acme/foo", explanation.LevelSource)
+	assert.Equal(t, "loggers/github.com/acme/**", explanation.SampleSource)
+
+	explanation = explain(config.withPatterns(t), "github.com/atomix/raft/driver/client")
+	assert.Equal(t, ErrorLevel, explanation.Level)
+	assert.Equal(t, "loggers/github.com/atomix/*/driver", explanation.LevelSource)
+}
+
+func TestValidateLoggerPatterns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFile)
+	writeFile(t, path, "loggers:\n  github.com/acme/**:\n    level: warn\n  re:github.com/(atomix:\n    level: debug\n")
+	err := loadFiles([]string{path}, nil, &loggingConfig{})
+	assert.EqualError(t, err, path+":4:3: invalid logger pattern 're:github.com/(atomix': error parsing regexp: missing closing ): `^(?:github.com/(atomix)$`")
+}
+
+// configureBuffer configures the root logger with the given configuration, writing to the returned buffer
+func configureBuffer(t *testing.T, config loggingConfig) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+	return buf
+}
+
+func (c loggingConfig) withPatterns(t *testing.T) loggingConfig {
+	patterns, err := newLoggerPatterns(c.Loggers)
+	assert.NoError(t, err)
+	c.patterns = patterns
+	return c
+}
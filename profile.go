@@ -0,0 +1,192 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sort"
+	"sync/atomic"
+)
+
+const profileKey = "profile"
+const profilesKey = "profiles"
+
+// profileEnv is the environment variable that selects the active configuration profile
+const profileEnv = "DAZL_PROFILE"
+
+// presets are the built-in profiles, keyed by profile name
+var presets = map[string]string{
+	"development": `
+encoders:
+  console:
+    fields:
+      - timestamp
+      - level:
+          format: uppercase-color
+      - name
+      - caller
+      - message
+writers:
+  stdout:
+    encoder: console
+rootLogger:
+  level: debug
+  outputs:
+    - stdout
+`,
+	"production": `
+encoders:
+  json:
+    fields:
+      - timestamp
+      - level
+      - name
+      - caller
+      - message
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: info
+  sample:
+    basic:
+      interval: 10
+      maxLevel: info
+  outputs:
+    - stdout
+`,
+	"test": `
+encoders:
+  console:
+    fields:
+      - level
+      - name
+      - message
+writers:
+  stdout:
+    encoder: console
+rootLogger:
+  level: warn
+  outputs:
+    - stdout
+`,
+}
+
+// Presets returns the names of the built-in configuration profiles
+func Presets() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+var selectedProfile atomic.Value
+
+// SetProfile sets the active configuration profile and applies the reloaded configuration to all existing loggers.
+// The profile overrides the profile selected by the DAZL_PROFILE environment variable and by the profile key in
+// the configuration files. If the name is empty, the profile is selected by the environment and the files again.
+func SetProfile(name string) error {
+	baseConfigMu.Lock()
+	defer baseConfigMu.Unlock()
+	previous := getSelectedProfile()
+	selectedProfile.Store(name)
+	inputs, err := layerConfigInputs(baseConfig)
+	if err == nil {
+		var source *configSource
+		if source, err = readInputs(inputs); err == nil {
+			err = reload(source, open)
+		}
+	}
+	if err != nil {
+		selectedProfile.Store(previous)
+		return err
+	}
+	return nil
+}
+
+func getSelectedProfile() string {
+	name, _ := selectedProfile.Load().(string)
+	return name
+}
+
+// activeProfile returns the name of the profile selected with SetProfile or by the DAZL_PROFILE environment
+// variable, or an empty string if the profile is selected by the configuration
+func activeProfile() string {
+	if name := getSelectedProfile(); name != "" {
+		return name
+	}
+	return os.Getenv(profileEnv)
+}
+
+// applyProfile merges the named profile into the configuration, or the profile selected by the profile key
+// in the configuration if the name is empty. The built-in preset with the profile's name, if any, is the base
+// of the merged configuration, and the profile with the name in the profiles mapping overrides the configuration.
+func (s *configSource) applyProfile(name string) error {
+	var selected *yaml.Node
+	if name == "" && s.node != nil {
+		if selected = lookup(s.node, profileKey); selected != nil && selected.Kind == yaml.ScalarNode {
+			name = selected.Value
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	preset, err := s.preset(name)
+	if err != nil {
+		return err
+	}
+	var profile *yaml.Node
+	if s.node != nil {
+		if profiles := lookup(s.node, profilesKey); profiles != nil && profiles.Kind == yaml.MappingNode {
+			profile = lookup(profiles, name)
+		}
+	}
+	if preset == nil && profile == nil {
+		if selected != nil {
+			return ConfigErrors{{
+				File:    s.nodes[selected],
+				Line:    selected.Line,
+				Column:  selected.Column,
+				Message: fmt.Sprintf("unknown profile '%s'", name),
+			}}
+		}
+		return fmt.Errorf("unknown profile '%s'", name)
+	}
+	s.node = mergeNodes(mergeNodes(preset, s.node), s.copy(profile))
+	return nil
+}
+
+// preset parses the built-in preset with the given name, returning nil if there is no such preset
+func (s *configSource) preset(name string) (*yaml.Node, error) {
+	data, ok := presets[name]
+	if !ok {
+		return nil, nil
+	}
+	path := fmt.Sprintf("%s preset", name)
+	node, err := parseConfig(path, yamlFormat, []byte(data))
+	if err != nil {
+		return nil, err
+	}
+	s.track(path, node)
+	return node, nil
+}
+
+// copy returns a deep copy of the given node, tracking each copy as read from the file of the copied node
+func (s *configSource) copy(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.Content = make([]*yaml.Node, len(node.Content))
+	for i, child := range node.Content {
+		copied.Content[i] = s.copy(child)
+	}
+	s.nodes[&copied] = s.nodes[node]
+	return &copied
+}
@@ -0,0 +1,171 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testProfilesConfig = `
+profile: development
+writers:
+  file:
+    path: /var/log/app.log
+    encoder: json
+loggers:
+  foo:
+    level: warn
+profiles:
+  development:
+    loggers:
+      foo:
+        level: debug
+  staging:
+    rootLogger:
+      level: info
+      outputs:
+        - file
+  production:
+    rootLogger:
+      outputs:
+        - file
+`
+
+func TestPresets(t *testing.T) {
+	assert.Equal(t, []string{"development", "production", "test"}, Presets())
+	levels := map[string]Level{
+		"development": DebugLevel,
+		"production":  InfoLevel,
+		"test":        WarnLevel,
+	}
+	dir := t.TempDir()
+	for _, name := range Presets() {
+		path := filepath.Join(dir, name+".yaml")
+		writeFile(t, path, "profile: "+name+"\n")
+		var config loggingConfig
+		assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &config), name)
+		assert.Equal(t, levels[name], config.RootLogger.Level.Level(), name)
+		assert.Contains(t, config.RootLogger.Outputs.Outputs, "stdout", name)
+	}
+}
+
+func TestProfiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, configFile)
+	writeFile(t, path, testProfilesConfig)
+
+	// The profile key selects the profile, which is merged over the preset and the configuration
+	var config loggingConfig
+	assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &config))
+	assert.Equal(t, DebugLevel, config.RootLogger.Level.Level())
+	assert.Equal(t, DebugLevel, config.Loggers["foo"].Level.Level())
+	assert.NotNil(t, config.Writers.Stdout)
+	assert.Contains(t, config.Writers.Files, "file")
+
+	// A profile without a preset is merged over the configuration
+	t.Setenv(profileEnv, "staging")
+	config = loggingConfig{}
+	assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &config))
+	assert.Equal(t, InfoLevel, config.RootLogger.Level.Level())
+	assert.Equal(t, WarnLevel, config.Loggers["foo"].Level.Level())
+	assert.Nil(t, config.Writers.Stdout)
+	assert.Contains(t, config.RootLogger.Outputs.Outputs, "file")
+	assert.NotContains(t, config.RootLogger.Outputs.Outputs, "stdout")
+
+	// The profile overrides the preset
+	t.Setenv(profileEnv, "production")
+	config = loggingConfig{}
+	assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &config))
+	assert.Equal(t, InfoLevel, config.RootLogger.Level.Level())
+	assert.NotNil(t, config.RootLogger.Sample.Basic)
+	assert.Contains(t, config.RootLogger.Outputs.Outputs, "file")
+	assert.NotContains(t, config.RootLogger.Outputs.Outputs, "stdout")
+
+	t.Setenv(profileEnv, "qa")
+	assert.EqualError(t, loadFiles([]string{path}, &defaultFramework{}, &loggingConfig{}), "unknown profile 'qa'")
+
+	t.Setenv(profileEnv, "")
+	writeFile(t, path, "profile: qa\n")
+	err := loadFiles([]string{path}, &defaultFramework{}, &loggingConfig{})
+	var configErrors ConfigErrors
+	assert.True(t, errors.As(err, &configErrors))
+	assert.Equal(t, []string{path + ":1:10: unknown profile 'qa'"}, errorStrings(configErrors))
+}
+
+func TestValidateProfiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, configFile)
+	writeFile(t, path, `writers:
+  file:
+    path: /var/log/app.log
+    encoder: json
+rootLogger:
+  level: loud
+profiles:
+  staging:
+    rootLogger:
+      outputs:
+        - file
+        - stderr
+    profile: production
+  production:
+    loggers:
+      foo:
+        level: verbose
+        outputs:
+          - stdout
+`)
+
+	err := loadFiles([]string{path}, &defaultFramework{}, &loggingConfig{})
+	var configErrors ConfigErrors
+	assert.True(t, errors.As(err, &configErrors))
+	assert.Equal(t, []string{
+		path + ":6:10: unknown level 'loud'",
+		path + ":12:11: root logger output references undefined writer 'stderr'",
+		path + ":13:5: unknown key 'profile' in profile 'staging'",
+		path + ":17:16: unknown level 'verbose'",
+	}, errorStrings(configErrors))
+}
+
+func TestSetProfile(t *testing.T) {
+	defer resetRootLogger()()
+	defer func() {
+		selectedProfile.Store("")
+	}()
+
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.NoError(t, os.Chdir(dir))
+	defer func() {
+		assert.NoError(t, os.Chdir(wd))
+	}()
+	writeFile(t, filepath.Join(dir, configFile), "profile: test\n")
+	assert.NoError(t, configure(&defaultFramework{}, loggingConfig{}, open))
+
+	assert.NoError(t, SetProfile("development"))
+	assert.Equal(t, DebugLevel, GetLogger("foo").Level())
+
+	// The profile selected with SetProfile overrides the environment
+	t.Setenv(profileEnv, "production")
+	assert.NoError(t, SetProfile("development"))
+	assert.Equal(t, DebugLevel, GetLogger("foo").Level())
+
+	assert.NoError(t, SetProfile(""))
+	assert.Equal(t, InfoLevel, GetLogger("foo").Level())
+
+	t.Setenv(profileEnv, "")
+	assert.NoError(t, SetProfile(""))
+	assert.Equal(t, WarnLevel, GetLogger("foo").Level())
+
+	// The previous profile is kept if the profile is unknown
+	assert.EqualError(t, SetProfile("qa"), "unknown profile 'qa'")
+	assert.Equal(t, WarnLevel, GetLogger("foo").Level())
+	assert.Equal(t, "", getSelectedProfile())
+}
@@ -53,6 +53,23 @@ type randomSamplerConfig struct {
 	Interval      int `json:"interval" yaml:"interval"`
 }
 
+// newSampler returns the sampler for the configuration, or nil if sampling is not configured
+func (c samplingConfig) newSampler() Sampler {
+	switch {
+	case c.Basic != nil:
+		return &basicSampler{
+			Interval: uint32(c.Basic.Interval),
+			MinLevel: c.Basic.MaxLevel.Level(),
+		}
+	case c.Random != nil:
+		return randomSampler{
+			Interval: c.Random.Interval,
+			MinLevel: c.Random.MaxLevel.Level(),
+		}
+	}
+	return nil
+}
+
 type Sampler interface {
 	Sample(level Level) bool
 }
@@ -0,0 +1,323 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package slog
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/atomix/dazl"
+	"io"
+	"log/slog"
+	"math"
+	"time"
+)
+
+const (
+	defaultNameKey       = "logger"
+	defaultMessageKey    = "message"
+	defaultLevelKey      = "level"
+	defaultTimestampKey  = "time"
+	defaultCallerKey     = "caller"
+	defaultStacktraceKey = "trace"
+)
+
+// encoderConfig is the set of encoder options applied to slog handlers.
+// An empty key indicates the field is disabled.
+type encoderConfig struct {
+	nameKey         string
+	messageKey      string
+	levelKey        string
+	levelFormat     dazl.LevelFormat
+	timestampKey    string
+	timestampFormat dazl.TimestampFormat
+	callerKey       string
+	callerFormat    dazl.CallerFormat
+	stacktraceKey   string
+}
+
+func (c encoderConfig) handlerOptions() *slog.HandlerOptions {
+	// Levels are filtered by dazl, so records are handled at all levels including custom levels
+	return &slog.HandlerOptions{
+		AddSource:   c.callerKey != "",
+		Level:       slog.Level(math.MinInt),
+		ReplaceAttr: c.replaceAttr,
+	}
+}
+
+// replaceAttr renames and formats the built-in slog attributes according to the encoder configuration
+func (c encoderConfig) replaceAttr(groups []string, attr slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return attr
+	}
+	switch attr.Key {
+	case slog.TimeKey:
+		if attr.Value.Kind() != slog.KindTime {
+			return attr
+		}
+		if c.timestampKey == "" {
+			return slog.Attr{}
+		}
+		t := attr.Value.Time()
+		switch c.timestampFormat {
+		case dazl.ISO8601TimestampFormat:
+			return slog.String(c.timestampKey, t.Format(dazl.ISO8601TimeLayout))
+		default:
+			return slog.Float64(c.timestampKey, float64(t.UnixNano())/float64(time.Second))
+		}
+	case slog.LevelKey:
+		level, ok := attr.Value.Any().(slog.Level)
+		if !ok {
+			return attr
+		}
+		if c.levelKey == "" {
+			return slog.Attr{}
+		}
+		return slog.String(c.levelKey, formatLevel(level, c.levelFormat))
+	case slog.SourceKey:
+		source, ok := attr.Value.Any().(*slog.Source)
+		if !ok {
+			return attr
+		}
+		if c.callerKey == "" {
+			return slog.Attr{}
+		}
+		return slog.String(c.callerKey, dazl.FormatCaller(source.File, source.Line, c.callerFormat))
+	case slog.MessageKey:
+		if c.messageKey == "" {
+			return slog.String(defaultMessageKey, attr.Value.String())
+		}
+		return slog.String(c.messageKey, attr.Value.String())
+	}
+	return attr
+}
+
+type slogEncoder struct {
+	config  encoderConfig
+	newFunc func(encoderConfig) dazl.Encoder
+}
+
+func (e *slogEncoder) with(f func(*encoderConfig)) dazl.Encoder {
+	config := e.config
+	f(&config)
+	return e.newFunc(config)
+}
+
+func (e *slogEncoder) WithNameEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.nameKey = defaultNameKey
+	}), nil
+}
+
+func (e *slogEncoder) WithLevelEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.levelKey = defaultLevelKey
+		config.levelFormat = dazl.LowerCaseLevelFormat
+	}), nil
+}
+
+func (e *slogEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.LowerCaseLevelFormat, dazl.UpperCaseLevelFormat:
+		return e.with(func(config *encoderConfig) {
+			config.levelFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported level format '%s'", format)
+	}
+}
+
+func (e *slogEncoder) WithTimestampEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.timestampKey = defaultTimestampKey
+		config.timestampFormat = dazl.UnixTimestampFormat
+	}), nil
+}
+
+func (e *slogEncoder) WithTimestampFormat(format dazl.TimestampFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.ISO8601TimestampFormat, dazl.UnixTimestampFormat:
+		return e.with(func(config *encoderConfig) {
+			config.timestampFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported time format '%s'", format)
+	}
+}
+
+func (e *slogEncoder) WithCallerEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.callerKey = defaultCallerKey
+		config.callerFormat = dazl.ShortCallerFormat
+	}), nil
+}
+
+func (e *slogEncoder) WithCallerFormat(format dazl.CallerFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.ShortCallerFormat, dazl.FullCallerFormat:
+		return e.with(func(config *encoderConfig) {
+			config.callerFormat = format
+		}), nil
+	default:
+		return nil, fmt.Errorf("unsupported caller format '%s'", format)
+	}
+}
+
+func (e *slogEncoder) WithStacktraceEnabled() (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.stacktraceKey = defaultStacktraceKey
+	}), nil
+}
+
+func newConsoleEncoder(config encoderConfig) dazl.Encoder {
+	return &consoleEncoder{
+		slogEncoder: &slogEncoder{
+			config:  config,
+			newFunc: newConsoleEncoder,
+		},
+	}
+}
+
+type consoleEncoder struct {
+	*slogEncoder
+}
+
+func (e *consoleEncoder) NewWriter(writer io.Writer) (dazl.Writer, error) {
+	switch e.config.levelFormat {
+	case dazl.LowerCaseColorLevelFormat, dazl.UpperCaseColorLevelFormat:
+		if e.config.levelKey != "" {
+			writer = &colorWriter{
+				out:    writer,
+				prefix: []byte(e.config.levelKey + "="),
+			}
+		}
+	}
+	return newWriter(slog.NewTextHandler(writer, e.config.handlerOptions()), e.config), nil
+}
+
+func (e *consoleEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.LowerCaseColorLevelFormat, dazl.UpperCaseColorLevelFormat:
+		return e.with(func(config *encoderConfig) {
+			config.levelFormat = format
+		}), nil
+	default:
+		return e.slogEncoder.WithLevelFormat(format)
+	}
+}
+
+// colorWriter colors the level names written by a text handler. Handlers quote values containing escape
+// sequences, so the level is colored in the handler's output rather than by replacing the level attribute.
+type colorWriter struct {
+	out    io.Writer
+	prefix []byte
+}
+
+func (w *colorWriter) Write(p []byte) (int, error) {
+	// Find the level attribute, which is the first attribute with the level key
+	i := 0
+	for {
+		j := bytes.Index(p[i:], w.prefix)
+		if j < 0 {
+			return w.out.Write(p)
+		}
+		i += j
+		if i == 0 || p[i-1] == ' ' {
+			break
+		}
+		i += len(w.prefix)
+	}
+	start := i + len(w.prefix)
+	end := bytes.IndexAny(p[start:], " \n")
+	if end < 0 {
+		return w.out.Write(p)
+	}
+	end += start
+
+	line := make([]byte, 0, len(p)+16)
+	line = append(line, p[:start]...)
+	line = append(line, colorLevel(string(p[start:end]))...)
+	line = append(line, p[end:]...)
+	if _, err := w.out.Write(line); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+var _ dazl.NameEncoder = (*consoleEncoder)(nil)
+var _ dazl.LevelEncoder = (*consoleEncoder)(nil)
+var _ dazl.LevelFormattingEncoder = (*consoleEncoder)(nil)
+var _ dazl.TimestampEncoder = (*consoleEncoder)(nil)
+var _ dazl.TimestampFormattingEncoder = (*consoleEncoder)(nil)
+var _ dazl.CallerEncoder = (*consoleEncoder)(nil)
+var _ dazl.CallerFormattingEncoder = (*consoleEncoder)(nil)
+var _ dazl.StacktraceEncoder = (*consoleEncoder)(nil)
+
+func newJSONEncoder(config encoderConfig) dazl.Encoder {
+	return &jsonEncoder{
+		slogEncoder: &slogEncoder{
+			config:  config,
+			newFunc: newJSONEncoder,
+		},
+	}
+}
+
+type jsonEncoder struct {
+	*slogEncoder
+}
+
+func (e *jsonEncoder) NewWriter(writer io.Writer) (dazl.Writer, error) {
+	return newWriter(slog.NewJSONHandler(writer, e.config.handlerOptions()), e.config), nil
+}
+
+func (e *jsonEncoder) WithMessageKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.messageKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithNameKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.nameKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithLevelKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.levelKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithTimestampKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.timestampKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithCallerKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.callerKey = key
+	}), nil
+}
+
+func (e *jsonEncoder) WithStacktraceKey(key string) (dazl.Encoder, error) {
+	return e.with(func(config *encoderConfig) {
+		config.stacktraceKey = key
+	}), nil
+}
+
+var _ dazl.MessageKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.NameEncoder = (*jsonEncoder)(nil)
+var _ dazl.NameKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.LevelEncoder = (*jsonEncoder)(nil)
+var _ dazl.LevelKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.LevelFormattingEncoder = (*jsonEncoder)(nil)
+var _ dazl.TimestampEncoder = (*jsonEncoder)(nil)
+var _ dazl.TimestampKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.TimestampFormattingEncoder = (*jsonEncoder)(nil)
+var _ dazl.CallerEncoder = (*jsonEncoder)(nil)
+var _ dazl.CallerKeyEncoder = (*jsonEncoder)(nil)
+var _ dazl.CallerFormattingEncoder = (*jsonEncoder)(nil)
+var _ dazl.StacktraceEncoder = (*jsonEncoder)(nil)
+var _ dazl.StacktraceKeyEncoder = (*jsonEncoder)(nil)
@@ -0,0 +1,225 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package slog
+
+import (
+	"bytes"
+	"encoding/json"
+	"github.com/atomix/dazl"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestJSONEncoder(t *testing.T) {
+	encoder := newJSONEncoder(encoderConfig{})
+	buf := &bytes.Buffer{}
+	writer, err := encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Debug("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.MessageKeyEncoder).WithMessageKey("msg")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.NameEncoder).WithNameEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Warn("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	writer = writer.WithName("test")
+	writer.Error("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\",\"logger\":\"test\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.NameKeyEncoder).WithNameKey("name")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer = writer.WithName("test")
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\",\"name\":\"test\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.LevelEncoder).WithLevelEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.LevelKeyEncoder).WithLevelKey("lvl")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Warn("Hello world!")
+	assert.Equal(t, "{\"lvl\":\"warn\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assert.Equal(t, "{\"lvl\":\"ERROR\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.CallerEncoder).WithCallerEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"lvl\":\"INFO\",\"caller\":\"slog/encoder_test.go:82\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.CallerKeyEncoder).WithCallerKey("call")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"slog/encoder_test.go:90\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.CallerFormattingEncoder).WithCallerFormat(dazl.ShortCallerFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.WithSkipCalls(0).Info("Hello world!")
+	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"slog/encoder_test.go:98\",\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.CallerFormattingEncoder).WithCallerFormat(dazl.FullCallerFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assertHasJSONKey(t, "call", buf.Bytes())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.TimestampEncoder).WithTimestampEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assertHasJSONKey(t, "time", buf.Bytes())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.TimestampKeyEncoder).WithTimestampKey("ts")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assertHasJSONKey(t, "ts", buf.Bytes())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.TimestampFormattingEncoder).WithTimestampFormat(dazl.UnixTimestampFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assertHasJSONKey(t, "ts", buf.Bytes())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.TimestampFormattingEncoder).WithTimestampFormat(dazl.ISO8601TimestampFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assertHasJSONKey(t, "ts", buf.Bytes())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.StacktraceEncoder).WithStacktraceEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assertHasJSONKey(t, "trace", buf.Bytes())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.StacktraceKeyEncoder).WithStacktraceKey("stack")
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assertHasJSONKey(t, "stack", buf.Bytes())
+	buf.Reset()
+}
+
+func TestConsoleEncoder(t *testing.T) {
+	encoder := newConsoleEncoder(encoderConfig{})
+	buf := &bytes.Buffer{}
+	writer, err := encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Debug("Hello world!")
+	assert.Equal(t, "message=\"Hello world!\"\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.NameEncoder).WithNameEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "message=\"Hello world!\"\n", buf.String())
+	buf.Reset()
+
+	writer = writer.WithName("test")
+	writer.Warn("Hello world!")
+	assert.Equal(t, "message=\"Hello world!\" logger=test\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.LevelEncoder).WithLevelEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assert.Equal(t, "level=error message=\"Hello world!\"\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Info("Hello world!")
+	assert.Equal(t, "level=INFO message=\"Hello world!\"\n", buf.String())
+	buf.Reset()
+
+	encoder, err = encoder.(dazl.CallerEncoder).WithCallerEnabled()
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Warn("Hello world!")
+	assert.Equal(t, "level=WARN caller=slog/encoder_test.go:201 message=\"Hello world!\"\n", buf.String())
+	buf.Reset()
+
+	encoder, err = newConsoleEncoder(encoderConfig{}).(dazl.LevelEncoder).WithLevelEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseColorLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assert.Equal(t, "level=\x1b[31mERROR\x1b[0m message=\"Hello world!\"\n", buf.String())
+	buf.Reset()
+
+	_, err = newJSONEncoder(encoderConfig{}).(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseColorLevelFormat)
+	assert.Error(t, err)
+}
+
+func assertHasJSONKey(t *testing.T, key string, data []byte) bool {
+	t.Helper()
+	object := make(map[string]any)
+	assert.NoError(t, json.Unmarshal(data, &object))
+	_, ok := object[key]
+	return assert.True(t, ok)
+}
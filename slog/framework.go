@@ -0,0 +1,27 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package slog
+
+import (
+	"github.com/atomix/dazl"
+)
+
+func init() {
+	dazl.Register(&Framework{})
+}
+
+type Framework struct{}
+
+func (f *Framework) Name() string {
+	return "slog"
+}
+
+func (f *Framework) ConsoleEncoder() dazl.Encoder {
+	return newConsoleEncoder(encoderConfig{})
+}
+
+func (f *Framework) JSONEncoder() dazl.Encoder {
+	return newJSONEncoder(encoderConfig{})
+}
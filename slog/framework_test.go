@@ -0,0 +1,17 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package slog
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestFramework(t *testing.T) {
+	framework := &Framework{}
+	assert.Equal(t, "slog", framework.Name())
+	assert.NotNil(t, framework.JSONEncoder())
+	assert.NotNil(t, framework.ConsoleEncoder())
+}
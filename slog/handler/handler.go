@@ -0,0 +1,160 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+// Package handler provides a slog.Handler that writes to dazl loggers with any logging framework.
+package handler
+
+import (
+	"context"
+	"fmt"
+	"github.com/atomix/dazl"
+	"log/slog"
+)
+
+// groupSep is the separator used to prefix the keys of attributes within slog groups
+const groupSep = "."
+
+// handlerSkipCalls is the number of slog frames between the slog.Logger caller and Handler.Handle
+const handlerSkipCalls = 3
+
+// New returns a slog.Handler that writes records to the given dazl Logger.
+// Records are filtered by the effective level of the Logger, and attributes are converted
+// to dazl fields, with the keys of attributes in groups prefixed by the group names.
+func New(logger dazl.Logger) slog.Handler {
+	return &Handler{
+		logger: logger,
+	}
+}
+
+// Handler is a slog.Handler that writes to a dazl Logger
+type Handler struct {
+	logger dazl.Logger
+	prefix string
+}
+
+func (h *Handler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.Level().Enabled(toLevel(level))
+}
+
+func (h *Handler) Handle(_ context.Context, record slog.Record) error {
+	fields := make([]dazl.Field, 0, record.NumAttrs())
+	record.Attrs(func(attr slog.Attr) bool {
+		fields = appendField(fields, h.prefix, attr)
+		return true
+	})
+
+	logger := h.logger.WithSkipCalls(handlerSkipCalls)
+	switch toLevel(record.Level) {
+	case dazl.TraceLevel:
+		logger.Tracew(record.Message, fields...)
+	case dazl.DebugLevel:
+		logger.Debugw(record.Message, fields...)
+	case dazl.InfoLevel:
+		logger.Infow(record.Message, fields...)
+	case dazl.WarnLevel:
+		logger.Warnw(record.Message, fields...)
+	default:
+		logger.Errorw(record.Message, fields...)
+	}
+	return nil
+}
+
+func (h *Handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	if len(attrs) == 0 {
+		return h
+	}
+	fields := make([]dazl.Field, 0, len(attrs))
+	for _, attr := range attrs {
+		fields = appendField(fields, h.prefix, attr)
+	}
+	return &Handler{
+		logger: h.logger.WithFields(fields...),
+		prefix: h.prefix,
+	}
+}
+
+func (h *Handler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &Handler{
+		logger: h.logger,
+		prefix: h.prefix + name + groupSep,
+	}
+}
+
+var _ slog.Handler = (*Handler)(nil)
+
+// toLevel maps a slog level to the dazl level at which it's logged
+func toLevel(level slog.Level) dazl.Level {
+	switch {
+	case level < slog.LevelDebug:
+		return dazl.TraceLevel
+	case level < slog.LevelInfo:
+		return dazl.DebugLevel
+	case level < slog.LevelWarn:
+		return dazl.InfoLevel
+	case level < slog.LevelError:
+		return dazl.WarnLevel
+	default:
+		return dazl.ErrorLevel
+	}
+}
+
+// appendField converts the given attribute to dazl fields, appending them to fields
+func appendField(fields []dazl.Field, prefix string, attr slog.Attr) []dazl.Field {
+	attr.Value = attr.Value.Resolve()
+	if attr.Equal(slog.Attr{}) {
+		return fields
+	}
+
+	if attr.Value.Kind() == slog.KindGroup {
+		if attr.Key != "" {
+			prefix = prefix + attr.Key + groupSep
+		}
+		for _, groupAttr := range attr.Value.Group() {
+			fields = appendField(fields, prefix, groupAttr)
+		}
+		return fields
+	}
+
+	name := prefix + attr.Key
+	switch attr.Value.Kind() {
+	case slog.KindString:
+		return append(fields, dazl.String(name, attr.Value.String()))
+	case slog.KindInt64:
+		return append(fields, dazl.Int64(name, attr.Value.Int64()))
+	case slog.KindUint64:
+		return append(fields, dazl.Uint64(name, attr.Value.Uint64()))
+	case slog.KindFloat64:
+		return append(fields, dazl.Float64(name, attr.Value.Float64()))
+	case slog.KindBool:
+		return append(fields, dazl.Bool(name, attr.Value.Bool()))
+	case slog.KindDuration:
+		return append(fields, dazl.Duration(name, attr.Value.Duration()))
+	case slog.KindTime:
+		return append(fields, dazl.Time(name, attr.Value.Time()))
+	}
+
+	switch value := attr.Value.Any().(type) {
+	case error:
+		return append(fields, dazl.String(name, value.Error()))
+	case fmt.Stringer:
+		return append(fields, dazl.Stringer(name, value))
+	case []string:
+		return append(fields, dazl.Strings(name, value))
+	case []bool:
+		return append(fields, dazl.Bools(name, value))
+	case []int:
+		return append(fields, dazl.Ints(name, value))
+	case []int64:
+		return append(fields, dazl.Int64s(name, value))
+	case []float64:
+		return append(fields, dazl.Float64s(name, value))
+	case []byte:
+		return append(fields, dazl.Bytes(name, value))
+	default:
+		return append(fields, dazl.String(name, fmt.Sprint(value)))
+	}
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"github.com/atomix/dazl"
+	dazlslog "github.com/atomix/dazl/slog"
+	"github.com/stretchr/testify/assert"
+	"log/slog"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	encoder, err := (&dazlslog.Framework{}).JSONEncoder().(dazl.LevelEncoder).WithLevelEnabled()
+	assert.NoError(t, err)
+	encoder, err = encoder.(dazl.CallerEncoder).WithCallerEnabled()
+	assert.NoError(t, err)
+
+	buf := &bytes.Buffer{}
+	writer, err := encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	logger := &testLogger{
+		writer: writer,
+		level:  dazl.InfoLevel,
+	}
+	log := slog.New(New(logger))
+
+	log.Debug("Hello world!")
+	assert.Equal(t, "", buf.String())
+	buf.Reset()
+
+	log.Info("Hello world!")
+	assert.Equal(t, "{\"level\":\"info\",\"caller\":\"handler/handler_test.go:37\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	log.Warn("Hello world!", "foo", "bar", "baz", 1)
+	assert.Equal(t, "{\"level\":\"warn\",\"caller\":\"handler/handler_test.go:41\",\"message\":\"Hello world!\",\"foo\":\"bar\",\"baz\":1}\n", buf.String())
+	buf.Reset()
+
+	log.Error("Hello world!", "error", errors.New("foo"))
+	assert.Equal(t, "{\"level\":\"error\",\"caller\":\"handler/handler_test.go:45\",\"message\":\"Hello world!\",\"error\":\"foo\"}\n", buf.String())
+	buf.Reset()
+
+	log.With("foo", "bar").WithGroup("a").With("b", true).Info("Hello world!", slog.Group("c", "d", 1.5))
+	assert.Equal(t, "{\"level\":\"info\",\"caller\":\"handler/handler_test.go:49\",\"message\":\"Hello world!\",\"foo\":\"bar\",\"a.b\":true,\"a.c.d\":1.5}\n", buf.String())
+	buf.Reset()
+
+	logger.level = dazl.DebugLevel
+	assert.True(t, log.Enabled(context.Background(), slog.LevelDebug))
+	log.Debug("Hello world!", "foo", []string{"bar"})
+	assert.Equal(t, "{\"level\":\"debug\",\"caller\":\"handler/handler_test.go:55\",\"message\":\"Hello world!\",\"foo\":[\"bar\"]}\n", buf.String())
+	buf.Reset()
+
+	assert.False(t, log.Enabled(context.Background(), slog.LevelDebug-4))
+	logger.level = dazl.TraceLevel
+	assert.True(t, log.Enabled(context.Background(), slog.LevelDebug-4))
+	log.Log(context.Background(), slog.LevelDebug-4, "Hello world!")
+	assert.Equal(t, "{\"level\":\"trace\",\"caller\":\"handler/handler_test.go:62\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	logger.level = dazl.ErrorLevel
+	assert.False(t, log.Enabled(context.Background(), slog.LevelWarn))
+	assert.True(t, log.Enabled(context.Background(), slog.LevelError))
+}
+
+// testLogger is a minimal dazl.Logger that applies fields to a single writer
+type testLogger struct {
+	dazl.Logger
+	writer dazl.Writer
+	level  dazl.Level
+}
+
+func (l *testLogger) Level() dazl.Level {
+	return l.level
+}
+
+func (l *testLogger) WithFields(fields ...dazl.Field) dazl.Logger {
+	writer := l.writer
+	for _, field := range fields {
+		var err error
+		if writer, err = field(writer); err != nil {
+			panic(err)
+		}
+	}
+	return &testLogger{
+		writer: writer,
+		level:  l.level,
+	}
+}
+
+func (l *testLogger) WithSkipCalls(calls int) dazl.Logger {
+	return &testLogger{
+		writer: l.writer.WithSkipCalls(calls),
+		level:  l.level,
+	}
+}
+
+func (l *testLogger) Tracew(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Trace(msg)
+}
+
+func (l *testLogger) Debugw(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Debug(msg)
+}
+
+func (l *testLogger) Infow(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Info(msg)
+}
+
+func (l *testLogger) Warnw(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Warn(msg)
+}
+
+func (l *testLogger) Errorw(msg string, fields ...dazl.Field) {
+	l.WithFields(fields...).WithSkipCalls(1).(*testLogger).writer.Error(msg)
+}
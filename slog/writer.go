@@ -0,0 +1,334 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package slog
+
+import (
+	"context"
+	"encoding/base64"
+	"github.com/atomix/dazl"
+	"log/slog"
+	"math"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	levelTrace = slog.LevelDebug - 4
+	levelPanic = slog.LevelError + 4
+	levelFatal = slog.LevelError + 8
+)
+
+// slog has no custom levels, and there are only three slog levels between neighbouring built-in levels, so
+// entries at custom dazl levels are written at unused slog levels allocated upward from the lowest 32-bit slog
+// level and encoded with the names of the custom levels. Each custom level has its own slog level, so levels
+// with neighbouring severities are never encoded with each other's names.
+var (
+	customLevels     sync.Map
+	customSlogLevels sync.Map
+	customLevelsMu   sync.Mutex
+	nextCustomLevel  = slog.Level(math.MinInt32)
+)
+
+// customSlogLevel returns the slog level at which entries at the given custom level are written
+func customSlogLevel(level dazl.Level) slog.Level {
+	if slogLevel, ok := customSlogLevels.Load(level); ok {
+		return slogLevel.(slog.Level)
+	}
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+	if slogLevel, ok := customSlogLevels.Load(level); ok {
+		return slogLevel.(slog.Level)
+	}
+	slogLevel := nextCustomLevel
+	nextCustomLevel++
+	customLevels.Store(slogLevel, level)
+	customSlogLevels.Store(level, slogLevel)
+	return slogLevel
+}
+
+// stacktraceLevel returns whether entries at the given slog level are written with a stacktrace, i.e. whether
+// the level is the error level or above
+func stacktraceLevel(level slog.Level) bool {
+	if custom, ok := customLevels.Load(level); ok {
+		return dazl.ErrorLevel.Enabled(custom.(dazl.Level))
+	}
+	return level >= slog.LevelError
+}
+
+// formatLevel returns the dazl name for the given slog level
+func formatLevel(level slog.Level, format dazl.LevelFormat) string {
+	var name string
+	custom, isCustom := customLevels.Load(level)
+	switch {
+	case isCustom:
+		name = custom.(dazl.Level).String()
+	case level < slog.LevelDebug:
+		name = dazl.TraceLevel.String()
+	case level < slog.LevelInfo:
+		name = dazl.DebugLevel.String()
+	case level < slog.LevelWarn:
+		name = dazl.InfoLevel.String()
+	case level < slog.LevelError:
+		name = dazl.WarnLevel.String()
+	case level < levelPanic:
+		name = dazl.ErrorLevel.String()
+	case level < levelFatal:
+		name = dazl.PanicLevel.String()
+	default:
+		name = dazl.FatalLevel.String()
+	}
+	switch format {
+	case dazl.UpperCaseLevelFormat, dazl.UpperCaseColorLevelFormat:
+		return strings.ToUpper(name)
+	}
+	return name
+}
+
+// colorLevel wraps the formatted level name in the ANSI color of the named level. Custom levels are colored
+// like the built-in level below them.
+func colorLevel(name string) string {
+	level, ok := parseLevel(strings.ToLower(name))
+	if !ok {
+		return name
+	}
+	return dazl.ColorLevel(level, name)
+}
+
+// parseLevel returns the built-in or custom dazl level with the given lower case name
+func parseLevel(name string) (dazl.Level, bool) {
+	for _, level := range []dazl.Level{dazl.TraceLevel, dazl.DebugLevel, dazl.InfoLevel, dazl.WarnLevel,
+		dazl.ErrorLevel, dazl.PanicLevel, dazl.FatalLevel} {
+		if name == level.String() {
+			return level, true
+		}
+	}
+	var custom dazl.Level
+	var ok bool
+	customLevels.Range(func(_, level any) bool {
+		if name == level.(dazl.Level).String() {
+			custom, ok = level.(dazl.Level), true
+			return false
+		}
+		return true
+	})
+	return custom, ok
+}
+
+func newWriter(handler slog.Handler, config encoderConfig) dazl.Writer {
+	return &Writer{
+		root:          handler,
+		handler:       handler,
+		nameKey:       config.nameKey,
+		stacktraceKey: config.stacktraceKey,
+	}
+}
+
+// Writer is a dazl output implementation
+type Writer struct {
+	root          slog.Handler
+	handler       slog.Handler
+	nameKey       string
+	stacktraceKey string
+	skipCalls     int
+}
+
+func (w *Writer) WithName(name string) dazl.Writer {
+	if w.nameKey == "" {
+		return w
+	}
+	return &Writer{
+		root:          w.root,
+		handler:       w.root.WithAttrs([]slog.Attr{slog.String(w.nameKey, name)}),
+		nameKey:       w.nameKey,
+		stacktraceKey: w.stacktraceKey,
+		skipCalls:     w.skipCalls,
+	}
+}
+
+func (w *Writer) WithSkipCalls(calls int) dazl.Writer {
+	return &Writer{
+		root:          w.root,
+		handler:       w.handler,
+		nameKey:       w.nameKey,
+		stacktraceKey: w.stacktraceKey,
+		skipCalls:     w.skipCalls + calls,
+	}
+}
+
+func (w *Writer) withAttr(attr slog.Attr) dazl.Writer {
+	return &Writer{
+		root:          w.root,
+		handler:       w.handler.WithAttrs([]slog.Attr{attr}),
+		nameKey:       w.nameKey,
+		stacktraceKey: w.stacktraceKey,
+		skipCalls:     w.skipCalls,
+	}
+}
+
+func (w *Writer) WithErrorField(err error) dazl.Writer {
+	return w.withAttr(slog.String("error", err.Error()))
+}
+
+func (w *Writer) WithStringField(name string, value string) dazl.Writer {
+	return w.withAttr(slog.String(name, value))
+}
+
+func (w *Writer) WithBoolField(name string, value bool) dazl.Writer {
+	return w.withAttr(slog.Bool(name, value))
+}
+
+func (w *Writer) WithIntField(name string, value int) dazl.Writer {
+	return w.withAttr(slog.Int(name, value))
+}
+
+func (w *Writer) WithInt32Field(name string, value int32) dazl.Writer {
+	return w.withAttr(slog.Int64(name, int64(value)))
+}
+
+func (w *Writer) WithInt64Field(name string, value int64) dazl.Writer {
+	return w.withAttr(slog.Int64(name, value))
+}
+
+func (w *Writer) WithUintField(name string, value uint) dazl.Writer {
+	return w.withAttr(slog.Uint64(name, uint64(value)))
+}
+
+func (w *Writer) WithUint32Field(name string, value uint32) dazl.Writer {
+	return w.withAttr(slog.Uint64(name, uint64(value)))
+}
+
+func (w *Writer) WithUint64Field(name string, value uint64) dazl.Writer {
+	return w.withAttr(slog.Uint64(name, value))
+}
+
+func (w *Writer) WithFloat32Field(name string, value float32) dazl.Writer {
+	return w.withAttr(slog.Float64(name, float64(value)))
+}
+
+func (w *Writer) WithFloat64Field(name string, value float64) dazl.Writer {
+	return w.withAttr(slog.Float64(name, value))
+}
+
+func (w *Writer) WithTimeField(name string, value time.Time) dazl.Writer {
+	return w.withAttr(slog.Time(name, value))
+}
+
+func (w *Writer) WithDurationField(name string, value time.Duration) dazl.Writer {
+	return w.withAttr(slog.Duration(name, value))
+}
+
+func (w *Writer) WithBinaryField(name string, value []byte) dazl.Writer {
+	return w.withAttr(slog.String(name, base64.StdEncoding.EncodeToString(value)))
+}
+
+func (w *Writer) WithBytesField(name string, value []byte) dazl.Writer {
+	return w.withAttr(slog.String(name, string(value)))
+}
+
+func (w *Writer) WithStringSliceField(name string, values []string) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithBoolSliceField(name string, values []bool) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithIntSliceField(name string, values []int) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithInt32SliceField(name string, values []int32) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithInt64SliceField(name string, values []int64) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithUintSliceField(name string, values []uint) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithUint32SliceField(name string, values []uint32) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithUint64SliceField(name string, values []uint64) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithFloat32SliceField(name string, values []float32) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithFloat64SliceField(name string, values []float64) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithTimeSliceField(name string, values []time.Time) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) WithDurationSliceField(name string, values []time.Duration) dazl.Writer {
+	return w.withAttr(slog.Any(name, values))
+}
+
+func (w *Writer) Trace(msg string) {
+	w.log(levelTrace, msg)
+}
+
+func (w *Writer) Debug(msg string) {
+	w.log(slog.LevelDebug, msg)
+}
+
+func (w *Writer) Info(msg string) {
+	w.log(slog.LevelInfo, msg)
+}
+
+func (w *Writer) Error(msg string) {
+	w.log(slog.LevelError, msg)
+}
+
+func (w *Writer) Fatal(msg string) {
+	w.log(levelFatal, msg)
+	os.Exit(1)
+}
+
+func (w *Writer) Panic(msg string) {
+	w.log(levelPanic, msg)
+	panic(msg)
+}
+
+func (w *Writer) Warn(msg string) {
+	w.log(slog.LevelWarn, msg)
+}
+
+func (w *Writer) Log(level dazl.Level, msg string) {
+	w.log(customSlogLevel(level), msg)
+}
+
+// log writes a record to the handler, attributing it to the caller of the Writer method
+func (w *Writer) log(level slog.Level, msg string) {
+	ctx := context.Background()
+	if !w.handler.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip runtime.Callers, log, and the Writer method
+	var pcs [1]uintptr
+	runtime.Callers(3+w.skipCalls, pcs[:])
+	record := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	if w.stacktraceKey != "" && stacktraceLevel(level) {
+		record.AddAttrs(slog.String(w.stacktraceKey, dazl.Stacktrace(4+w.skipCalls)))
+	}
+	_ = w.handler.Handle(ctx, record)
+}
+
+var _ dazl.Writer = (*Writer)(nil)
+var _ dazl.FieldWriter = (*Writer)(nil)
+var _ dazl.ErrorFieldWriter = (*Writer)(nil)
@@ -0,0 +1,97 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"fmt"
+	"log"
+	"regexp"
+	"runtime"
+	"strings"
+)
+
+// stdLogHeader matches the date, time and file headers written by the standard logger
+var stdLogHeader = regexp.MustCompile(`^(\d{4}/\d{2}/\d{2} )?(\d{2}:\d{2}:\d{2}(\.\d{6})? )?((\S+\.go|\?\?\?):\d+: )?`)
+
+// RedirectStdLog redirects the output of the standard library's log package to the given logger at the given level.
+// The date, time and file headers written by the standard logger are stripped from each entry, and entries are
+// attributed to the caller of the log package. The returned function restores the original output of the
+// standard logger.
+func RedirectStdLog(logger Logger, level Level) (func(), error) {
+	switch level {
+	case TraceLevel, DebugLevel, InfoLevel, WarnLevel, ErrorLevel:
+	default:
+		return nil, fmt.Errorf("cannot redirect standard log output at level '%s'", level)
+	}
+
+	flags := log.Flags()
+	prefix := log.Prefix()
+	output := log.Writer()
+	log.SetFlags(0)
+	log.SetPrefix("")
+	log.SetOutput(&stdLogWriter{
+		logger: logger,
+		level:  level,
+	})
+	return func() {
+		log.SetFlags(flags)
+		log.SetPrefix(prefix)
+		log.SetOutput(output)
+	}, nil
+}
+
+// stdLogWriter is an io.Writer that writes standard log entries to a Logger
+type stdLogWriter struct {
+	logger Logger
+	level  Level
+}
+
+func (w *stdLogWriter) Write(p []byte) (int, error) {
+	msg := parseStdLog(string(p))
+	logger := w.logger.WithSkipCalls(stdLogSkipCalls())
+	switch w.level {
+	case TraceLevel:
+		logger.Trace(msg)
+	case DebugLevel:
+		logger.Debug(msg)
+	case InfoLevel:
+		logger.Info(msg)
+	case WarnLevel:
+		logger.Warn(msg)
+	case ErrorLevel:
+		logger.Error(msg)
+	}
+	return len(p), nil
+}
+
+// parseStdLog strips the headers and trailing newline from a standard log entry
+func parseStdLog(entry string) string {
+	entry = strings.TrimSuffix(entry, "\n")
+	if loc := stdLogHeader.FindStringIndex(entry); loc != nil {
+		entry = entry[loc[1]:]
+	}
+	return entry
+}
+
+// stdLogSkipCalls returns the number of frames between the Logger and the caller of the log package,
+// including stdLogWriter.Write and the log package frames
+func stdLogSkipCalls() int {
+	// Skip runtime.Callers, stdLogSkipCalls and stdLogWriter.Write
+	pcs := make([]uintptr, 16)
+	n := runtime.Callers(3, pcs)
+	frames := runtime.CallersFrames(pcs[:n])
+	calls := 1
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, "log.") {
+			break
+		}
+		calls++
+		if !more {
+			break
+		}
+	}
+	return calls
+}
@@ -0,0 +1,78 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io"
+	"log"
+	"testing"
+)
+
+const testStdLogConfig = `
+encoders:
+  json:
+    fields:
+      - message
+      - level
+      - caller
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: debug
+  outputs:
+    - stdout
+`
+
+func TestRedirectStdLog(t *testing.T) {
+	defer resetRootLogger()()
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testStdLogConfig), &config))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+
+	_, err := RedirectStdLog(GetRootLogger(), FatalLevel)
+	assert.Error(t, err)
+
+	flags := log.Flags()
+	restore, err := RedirectStdLog(GetRootLogger(), WarnLevel)
+	assert.NoError(t, err)
+
+	log.Printf("Hello %s!", "world")
+	assert.Regexp(t, `^\{"level":"warn","caller":"[^/]+/stdlog_test.go:50","message":"Hello world!"\}\n$`, buf.String())
+	buf.Reset()
+
+	log.SetFlags(log.LstdFlags | log.Lmicroseconds | log.Lshortfile)
+	log.Println("Hello world!")
+	assert.Regexp(t, `^\{"level":"warn","caller":"[^/]+/stdlog_test.go:55","message":"Hello world!"\}\n$`, buf.String())
+	buf.Reset()
+
+	log.New(log.Writer(), "", log.Llongfile).Print("Hello world!")
+	assert.Contains(t, buf.String(), `"message":"Hello world!"`)
+	buf.Reset()
+
+	restore()
+	assert.Equal(t, flags, log.Flags())
+	assert.NotEqual(t, log.Writer(), buf)
+	assert.Equal(t, "Hello world!", parseStdLog("2009/01/23 01:23:23 Hello world!\n"))
+	assert.Equal(t, "code:404: not found", parseStdLog("code:404: not found\n"))
+	assert.Equal(t, "Hello world!", parseStdLog("2009/01/23 01:23:23.123123 /a/b/c/d.go:23: Hello world!\n"))
+
+	restore, err = RedirectStdLog(GetRootLogger(), TraceLevel)
+	assert.NoError(t, err)
+	log.Print("Hello world!")
+	assert.Empty(t, buf.String())
+	GetRootLogger().SetLevel(TraceLevel)
+	log.Print("Hello world!")
+	assert.Contains(t, buf.String(), `"level":"trace"`)
+	restore()
+}
@@ -0,0 +1,87 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"context"
+	"sync"
+)
+
+const (
+	defaultTraceIDKey    = "trace_id"
+	defaultSpanIDKey     = "span_id"
+	defaultTraceFlagsKey = "trace_flags"
+)
+
+var (
+	spanContextProvider   SpanContextProvider
+	spanContextProviderMu sync.RWMutex
+)
+
+// SpanContext identifies the trace span carried by a context
+type SpanContext struct {
+	TraceID    string
+	SpanID     string
+	TraceFlags string
+}
+
+// SpanContextProvider reads the SpanContext carried by a context
+type SpanContextProvider interface {
+	// SpanContextFromContext returns the SpanContext carried by the given context, if any
+	SpanContextFromContext(ctx context.Context) (SpanContext, bool)
+}
+
+// RegisterSpanContextProvider registers the provider used to add trace fields to entries written by
+// a Logger returned by WithContext. Tracing integrations typically register a provider when imported.
+func RegisterSpanContextProvider(provider SpanContextProvider) {
+	spanContextProviderMu.Lock()
+	defer spanContextProviderMu.Unlock()
+	spanContextProvider = provider
+}
+
+// spanContextFromContext returns the SpanContext carried by the given context using the registered provider
+func spanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	spanContextProviderMu.RLock()
+	provider := spanContextProvider
+	spanContextProviderMu.RUnlock()
+	if provider == nil || ctx == nil {
+		return SpanContext{}, false
+	}
+	return provider.SpanContextFromContext(ctx)
+}
+
+// traceKeys are the keys of the trace fields written by an encoder
+type traceKeys struct {
+	traceID    string
+	spanID     string
+	traceFlags string
+}
+
+func newTraceKeys(config encoderFieldsConfig) traceKeys {
+	keys := traceKeys{
+		traceID:    defaultTraceIDKey,
+		spanID:     defaultSpanIDKey,
+		traceFlags: defaultTraceFlagsKey,
+	}
+	if config.TraceID != nil && config.TraceID.Key != "" {
+		keys.traceID = config.TraceID.Key
+	}
+	if config.SpanID != nil && config.SpanID.Key != "" {
+		keys.spanID = config.SpanID.Key
+	}
+	if config.TraceFlags != nil && config.TraceFlags.Key != "" {
+		keys.traceFlags = config.TraceFlags.Key
+	}
+	return keys
+}
+
+// fields returns the trace fields for the given SpanContext
+func (k traceKeys) fields(spanContext SpanContext) []Field {
+	return []Field{
+		String(k.traceID, spanContext.TraceID),
+		String(k.spanID, spanContext.SpanID),
+		String(k.traceFlags, spanContext.TraceFlags),
+	}
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"context"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+	"io"
+	"testing"
+)
+
+const testTraceConfig = `
+encoders:
+  json:
+    fields:
+      - message
+      - traceId:
+          key: trace.id
+      - spanId:
+          key: span.id
+writers:
+  stdout:
+    encoder: json
+  stderr:
+    encoder: console
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+`
+
+type testSpanContextKey struct{}
+
+type testSpanContextProvider struct{}
+
+func (p *testSpanContextProvider) SpanContextFromContext(ctx context.Context) (SpanContext, bool) {
+	spanContext, ok := ctx.Value(testSpanContextKey{}).(SpanContext)
+	return spanContext, ok
+}
+
+func TestTraceFields(t *testing.T) {
+	defer resetRootLogger()()
+	defer RegisterSpanContextProvider(nil)
+
+	var config loggingConfig
+	assert.NoError(t, yaml.Unmarshal([]byte(testTraceConfig), &config))
+
+	buf := &bytes.Buffer{}
+	assert.NoError(t, configure(&defaultFramework{}, config, func(path string) (io.Writer, error) {
+		return buf, nil
+	}))
+
+	ctx := context.WithValue(context.Background(), testSpanContextKey{}, SpanContext{
+		TraceID:    "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanID:     "00f067aa0ba902b7",
+		TraceFlags: "01",
+	})
+
+	GetRootLogger().WithContext(ctx).Info("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	RegisterSpanContextProvider(&testSpanContextProvider{})
+	GetRootLogger().WithContext(context.Background()).Info("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	GetRootLogger().WithContext(ContextWithFields(ctx, String("foo", "bar"))).Info("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\",\"trace.id\":\"4bf92f3577b34da6a3ce929d0e0e4736\",\"span.id\":\"00f067aa0ba902b7\",\"trace_flags\":\"01\",\"foo\":\"bar\"}\n", buf.String())
+
+	keys := root.(*dazlLogger).getState().getTraceKeys("stderr")
+	assert.Equal(t, traceKeys{traceID: "trace_id", spanID: "span_id", traceFlags: "trace_flags"}, keys)
+}
@@ -0,0 +1,538 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"fmt"
+	"gopkg.in/yaml.v3"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ConfigError is a problem at a position in a configuration file. The line and column are zero if the position
+// is not known, e.g. for problems in TOML files.
+type ConfigError struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (e *ConfigError) Error() string {
+	if e.Line == 0 {
+		if e.File == "" {
+			return e.Message
+		}
+		return fmt.Sprintf("%s: %s", e.File, e.Message)
+	}
+	if e.File == "" {
+		return fmt.Sprintf("%d:%d: %s", e.Line, e.Column, e.Message)
+	}
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Line, e.Column, e.Message)
+}
+
+// ConfigErrors is the set of problems found in a configuration
+type ConfigErrors []*ConfigError
+
+func (e ConfigErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return strings.Join(messages, "\n")
+}
+
+// ValidateFiles checks the configuration merged from the files at the given paths, returning ConfigErrors
+// describing all problems found. If the framework is not nil, the configuration is also checked against the
+// encodings and encoder options supported by the framework.
+func ValidateFiles(framework Framework, paths ...string) error {
+	var config loggingConfig
+	return loadFiles(paths, framework, &config)
+}
+
+// validate checks the configuration for unknown keys, invalid values, references to undefined writers and
+// encoder options that are not supported by the given framework, returning all problems found as ConfigErrors
+func validate(source *configSource, framework Framework) error {
+	if source.node == nil {
+		return nil
+	}
+	v := &validator{
+		source:    source,
+		framework: framework,
+	}
+	v.validate(source.node)
+	if len(v.errors) > 0 {
+		sort.SliceStable(v.errors, func(i, j int) bool {
+			a, b := v.errors[i], v.errors[j]
+			if a.File != b.File {
+				return a.File < b.File
+			}
+			if a.Line != b.Line {
+				return a.Line < b.Line
+			}
+			return a.Column < b.Column
+		})
+		return v.errors
+	}
+	return nil
+}
+
+type validator struct {
+	source    *configSource
+	framework Framework
+	writers   map[string]bool
+	levels    map[string]bool
+	errors    ConfigErrors
+	found     map[ConfigError]bool
+}
+
+// errorf records a problem at the given node. Problems found more than once, e.g. in the configuration
+// shared by multiple profiles, are recorded once.
+func (v *validator) errorf(node *yaml.Node, format string, args ...any) {
+	err := ConfigError{
+		File:    v.source.nodes[node],
+		Line:    node.Line,
+		Column:  node.Column,
+		Message: fmt.Sprintf(format, args...),
+	}
+	if v.found[err] {
+		return
+	}
+	if v.found == nil {
+		v.found = make(map[ConfigError]bool)
+	}
+	v.found[err] = true
+	v.errors = append(v.errors, &err)
+}
+
+// keyValue is a key and its value in a mapping node
+type keyValue struct {
+	key   *yaml.Node
+	value *yaml.Node
+}
+
+// mapping returns the entries of the given mapping node, reporting keys that are not in the given set of keys.
+// If no keys are given, all keys are allowed.
+func (v *validator) mapping(node *yaml.Node, name string, keys ...string) []keyValue {
+	if isNull(node) {
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		v.errorf(node, "%s must be a mapping", name)
+		return nil
+	}
+	var entries []keyValue
+	for i := 0; i < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if len(keys) > 0 && !contains(keys, key.Value) {
+			v.errorf(key, "unknown key '%s' in %s", key.Value, name)
+			continue
+		}
+		entries = append(entries, keyValue{key: key, value: value})
+	}
+	return entries
+}
+
+// configKeys are the keys of a configuration or a profile
+var configKeys = []string{levelsKey, "encoders", "writers", "rootLogger", "loggers"}
+
+func (v *validator) validate(node *yaml.Node) {
+	entries := v.mapping(node, "configuration", append(configKeys, profileKey, profilesKey)...)
+	v.validateConfig(entries)
+	for _, entry := range entries {
+		switch entry.key.Value {
+		case profileKey:
+			if entry.value.Kind != yaml.ScalarNode || isNull(entry.value) {
+				v.errorf(entry.value, "profile must be a profile name")
+			}
+		case profilesKey:
+			v.validateProfiles(node, entry.value)
+		}
+	}
+}
+
+func (v *validator) validateConfig(entries []keyValue) {
+	// Custom levels and writers are validated first so logger levels and outputs can be checked against them
+	v.levels = make(map[string]bool)
+	v.writers = make(map[string]bool)
+	for _, entry := range entries {
+		switch entry.key.Value {
+		case levelsKey:
+			v.validateLevels(entry.value)
+		case "writers":
+			v.validateWriters(entry.value)
+		}
+	}
+	for _, entry := range entries {
+		switch entry.key.Value {
+		case "encoders":
+			v.validateEncoders(entry.value)
+		case "rootLogger":
+			v.validateLogger(entry.value, "root logger")
+		case "loggers":
+			for _, logger := range v.mapping(entry.value, "loggers") {
+				if isLoggerPattern(logger.key.Value) {
+					if _, err := newLoggerPattern(logger.key.Value); err != nil {
+						v.errorf(logger.key, "%s", err)
+					}
+				}
+				v.validateLogger(logger.value, fmt.Sprintf("logger '%s'", logger.key.Value))
+			}
+		}
+	}
+}
+
+// validateProfiles checks each profile merged into the given configuration as it would be if the profile
+// were active, so references to writers defined outside the profile are resolved
+func (v *validator) validateProfiles(config, node *yaml.Node) {
+	for _, profile := range v.mapping(node, "profiles") {
+		name := profile.key.Value
+		merged := &yaml.Node{Kind: yaml.MappingNode}
+		for _, entry := range v.mapping(config, "configuration") {
+			if contains(configKeys, entry.key.Value) {
+				merged.Content = append(merged.Content, entry.key, v.source.copy(entry.value))
+			}
+		}
+		overrides := &yaml.Node{Kind: yaml.MappingNode}
+		for _, entry := range v.mapping(profile.value, fmt.Sprintf("profile '%s'", name), configKeys...) {
+			overrides.Content = append(overrides.Content, entry.key, v.source.copy(entry.value))
+		}
+		preset, err := v.source.preset(name)
+		if err != nil {
+			v.errorf(profile.key, "%s", err)
+			continue
+		}
+		v.validateConfig(v.mapping(mergeNodes(mergeNodes(preset, merged), overrides), "configuration"))
+	}
+}
+
+func (v *validator) validateEncoders(node *yaml.Node) {
+	for _, entry := range v.mapping(node, "encoders", string(ConsoleEncoding), string(JSONEncoding)) {
+		encoding := Encoding(entry.key.Value)
+		for _, field := range v.mapping(entry.value, fmt.Sprintf("%s encoder", encoding), "fields") {
+			v.validateFields(encoding, field.value)
+		}
+	}
+}
+
+func (v *validator) validateFields(encoding Encoding, node *yaml.Node) {
+	var fields []keyValue
+	switch node.Kind {
+	case yaml.MappingNode:
+		fields = v.mapping(node, "fields")
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			switch item.Kind {
+			case yaml.ScalarNode:
+				fields = append(fields, keyValue{key: item})
+			case yaml.MappingNode:
+				if len(item.Content) != 2 {
+					v.errorf(item, "encoder fields must configure one encoder per list item")
+					continue
+				}
+				fields = append(fields, keyValue{key: item.Content[0], value: item.Content[1]})
+			default:
+				v.errorf(item, "encoder field must be a field name or a mapping")
+			}
+		}
+	default:
+		if !isNull(node) {
+			v.errorf(node, "fields must be a list or a mapping")
+		}
+		return
+	}
+
+	for _, field := range fields {
+		name := fieldEncoderName(field.key.Value)
+		var options []string
+		switch name {
+		case nameFieldName, messageFieldName, stacktraceFieldName, traceIDFieldName, spanIDFieldName, traceFlagsFieldName:
+			options = []string{"key"}
+		case levelFieldName, timestampFieldName, callerFieldName:
+			options = []string{"key", "format"}
+		default:
+			v.errorf(field.key, "unknown field encoder '%s'", name)
+			continue
+		}
+
+		valid := true
+		if field.value != nil {
+			errors := len(v.errors)
+			for _, option := range v.mapping(field.value, fmt.Sprintf("%s field", name), options...) {
+				if option.key.Value == "format" {
+					v.validateFormat(name, option.value)
+				}
+			}
+			valid = len(v.errors) == errors
+		}
+		if valid {
+			v.validateFieldSupport(encoding, field)
+		}
+	}
+}
+
+func (v *validator) validateFormat(name fieldEncoderName, node *yaml.Node) {
+	var err error
+	switch name {
+	case levelFieldName:
+		var format LevelFormat
+		err = format.UnmarshalText([]byte(node.Value))
+	case timestampFieldName:
+		var format TimestampFormat
+		err = format.UnmarshalText([]byte(node.Value))
+	case callerFieldName:
+		var format CallerFormat
+		err = format.UnmarshalText([]byte(node.Value))
+	}
+	if err != nil {
+		v.errorf(node, "%s", err)
+	}
+}
+
+// validateFieldSupport checks whether the framework's encoder supports the given field configuration
+func (v *validator) validateFieldSupport(encoding Encoding, field keyValue) {
+	if v.framework == nil {
+		return
+	}
+	value := field.value
+	if value == nil {
+		value = &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!null"}
+	}
+	var fields encoderFieldsConfig
+	if err := (&yaml.Node{Kind: yaml.MappingNode, Content: []*yaml.Node{field.key, value}}).Decode(&fields); err != nil {
+		v.errorf(field.key, "%s", err)
+		return
+	}
+	config := encoderConfig{Fields: fields}
+	var err error
+	switch encoding {
+	case ConsoleEncoding:
+		if framework, ok := v.framework.(ConsoleEncodingFramework); ok {
+			_, err = configureConsoleEncoder(config, framework.ConsoleEncoder())
+		}
+	case JSONEncoding:
+		if framework, ok := v.framework.(JSONEncodingFramework); ok {
+			_, err = configureJSONEncoder(config, framework.JSONEncoder())
+		}
+	}
+	if err != nil {
+		v.errorf(field.key, "%s framework: %s", v.framework.Name(), err)
+	}
+}
+
+func (v *validator) validateWriters(node *yaml.Node) {
+	for _, entry := range v.mapping(node, "writers") {
+		name := entry.key.Value
+		v.writers[name] = true
+		options := []string{"encoder"}
+		if name != "stdout" && name != "stderr" {
+			options = append(options, "path")
+		}
+		var encoder, path *yaml.Node
+		for _, option := range v.mapping(entry.value, fmt.Sprintf("writer '%s'", name), options...) {
+			switch option.key.Value {
+			case "encoder":
+				encoder = option.value
+			case "path":
+				path = option.value
+			}
+		}
+		if encoder == nil || encoder.Value == "" {
+			v.errorf(entry.key, "writer '%s' is missing required encoder", name)
+		} else {
+			v.validateEncoding(encoder)
+		}
+		if len(options) > 1 && (path == nil || path.Value == "") {
+			v.errorf(entry.key, "writer '%s' is missing required path", name)
+		}
+	}
+}
+
+func (v *validator) validateEncoding(node *yaml.Node) {
+	encoding := Encoding(node.Value)
+	switch encoding {
+	case ConsoleEncoding:
+		if _, ok := v.framework.(ConsoleEncodingFramework); v.framework != nil && !ok {
+			v.errorf(node, "%s framework does not support %s encoding", v.framework.Name(), encoding)
+		}
+	case JSONEncoding:
+		if _, ok := v.framework.(JSONEncodingFramework); v.framework != nil && !ok {
+			v.errorf(node, "%s framework does not support %s encoding", v.framework.Name(), encoding)
+		}
+	default:
+		v.errorf(node, "unknown encoding '%s'", encoding)
+	}
+}
+
+func (v *validator) validateLogger(node *yaml.Node, name string) {
+	for _, entry := range v.mapping(node, name, "level", "sample", "outputs") {
+		switch entry.key.Value {
+		case "level":
+			v.validateLevel(entry.value)
+		case "sample":
+			v.validateSample(entry.value)
+		case "outputs":
+			v.validateOutputs(entry.value, name)
+		}
+	}
+}
+
+// validateLevels checks the custom levels for invalid names and severities, and for severities that conflict with
+// the built-in levels, the registered custom levels or other configured levels
+func (v *validator) validateLevels(node *yaml.Node) {
+	severities := make(map[Level]string)
+	for _, entry := range v.mapping(node, "levels") {
+		name := entry.key.Value
+		if err := validateLevelName(name); err != nil {
+			v.errorf(entry.key, "%s", err)
+			continue
+		}
+		if _, ok := parseBuiltinLevel(name); ok {
+			v.errorf(entry.key, "level '%s' is a built-in level", name)
+			continue
+		}
+		severityNode := entry.value
+		if entry.value.Kind == yaml.MappingNode {
+			severityNode = nil
+			for _, option := range v.mapping(entry.value, fmt.Sprintf("level '%s'", name), "severity", "unfiltered") {
+				switch option.key.Value {
+				case "severity":
+					severityNode = option.value
+				case "unfiltered":
+					if _, err := strconv.ParseBool(option.value.Value); err != nil || option.value.Kind != yaml.ScalarNode {
+						v.errorf(option.value, "unfiltered must be true or false")
+					}
+				}
+			}
+			if severityNode == nil {
+				v.errorf(entry.key, "level '%s' must configure a severity", name)
+				continue
+			}
+		}
+		severity, err := strconv.ParseInt(severityNode.Value, 10, 32)
+		if err != nil || severityNode.Kind != yaml.ScalarNode {
+			v.errorf(severityNode, "severity of level '%s' must be an integer", name)
+			continue
+		}
+		level := Level(severity)
+		if level <= TraceLevel.severity() {
+			v.errorf(severityNode, "severity %d of level '%s' must be above the trace level (%d)", level, name, TraceLevel.severity())
+			continue
+		}
+		if builtin, ok := builtinSeverity(level); ok {
+			v.errorf(severityNode, "severity %d of level '%s' is used by level '%s'", level, name, builtin)
+			continue
+		}
+		if registered, ok := getLevels().byName[name]; ok && registered.level != level {
+			v.errorf(severityNode, "level '%s' is already registered with severity %d", name, registered.level)
+			continue
+		}
+		if registered, ok := getLevels().byLevel[level]; ok && registered.name != name {
+			v.errorf(severityNode, "severity %d of level '%s' is already registered for level '%s'", level, name, registered.name)
+			continue
+		}
+		if other, ok := severities[level]; ok {
+			v.errorf(severityNode, "severity %d of level '%s' is already configured for level '%s'", level, name, other)
+			continue
+		}
+		severities[level] = name
+		v.levels[name] = true
+	}
+}
+
+func (v *validator) validateLevel(node *yaml.Node) {
+	// An empty level is inherited like an unset level
+	if isNull(node) || (node.Kind == yaml.ScalarNode && node.Value == "") {
+		return
+	}
+	if _, ok := parseLevel(node.Value); (!ok && !v.levels[node.Value]) || node.Kind != yaml.ScalarNode {
+		v.errorf(node, "unknown level '%s'", node.Value)
+	}
+}
+
+func (v *validator) validateSample(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode && !isNull(node) {
+		switch samplingStintervalgy(node.Value) {
+		case basicSamplingStintervalgy, randomSamplingStintervalgy:
+		default:
+			v.errorf(node, "unknown sampler '%s'", node.Value)
+		}
+		return
+	}
+	entries := v.mapping(node, "sample", string(basicSamplingStintervalgy), string(randomSamplingStintervalgy))
+	if len(entries) > 1 {
+		v.errorf(node, "only one of basic or random sampling may be configured")
+	}
+	for _, entry := range entries {
+		for _, option := range v.mapping(entry.value, fmt.Sprintf("%s sampler", entry.key.Value), "interval", "maxLevel") {
+			switch option.key.Value {
+			case "interval":
+				if interval, err := strconv.Atoi(option.value.Value); err != nil || interval <= 0 {
+					v.errorf(option.value, "sampling interval must be a positive integer")
+				}
+			case "maxLevel":
+				v.validateLevel(option.value)
+			}
+		}
+	}
+}
+
+func (v *validator) validateOutputs(node *yaml.Node, name string) {
+	var outputs []keyValue
+	switch node.Kind {
+	case yaml.MappingNode:
+		outputs = v.mapping(node, "outputs")
+	case yaml.SequenceNode:
+		for _, item := range node.Content {
+			switch item.Kind {
+			case yaml.ScalarNode:
+				outputs = append(outputs, keyValue{key: item})
+			case yaml.MappingNode:
+				if len(item.Content) != 2 {
+					v.errorf(item, "logger outputs must configure one writer per list item")
+					continue
+				}
+				outputs = append(outputs, keyValue{key: item.Content[0], value: item.Content[1]})
+			default:
+				v.errorf(item, "logger output must be a writer name or a mapping")
+			}
+		}
+	default:
+		if !isNull(node) {
+			v.errorf(node, "outputs must be a list or a mapping")
+		}
+		return
+	}
+
+	for _, output := range outputs {
+		if !v.writers[output.key.Value] {
+			v.errorf(output.key, "%s output references undefined writer '%s'", name, output.key.Value)
+		}
+		if output.value != nil {
+			for _, option := range v.mapping(output.value, fmt.Sprintf("output '%s'", output.key.Value), "level", "sample") {
+				switch option.key.Value {
+				case "level":
+					v.validateLevel(option.value)
+				case "sample":
+					v.validateSample(option.value)
+				}
+			}
+		}
+	}
+}
+
+func isNull(node *yaml.Node) bool {
+	return node == nil || (node.Kind == yaml.ScalarNode && node.ShortTag() == "!!null")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
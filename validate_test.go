@@ -0,0 +1,159 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"path/filepath"
+	"testing"
+)
+
+const testInvalidConfig = `encoders:
+  json:
+    fields:
+      - message
+      - colour
+      - level:
+          format: shouting
+  console:
+    fields:
+      timestamp:
+        keys: ts
+writers:
+  stdout:
+    encoder: json
+  file:
+    encoder: xml
+rootLogger:
+  level: verbose
+  outputs:
+    - stdout
+    - stderr
+loggers:
+  foo:
+    sample:
+      basic:
+        interval: 0
+    outputs:
+      file:
+        level: loud
+  bar:
+    levels: debug
+`
+
+const testIncludedInvalidConfig = `loggers:
+  baz:
+    level: chatty
+`
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, filepath.Join(dir, "included.yaml"), testIncludedInvalidConfig)
+	writeFile(t, filepath.Join(dir, configFile), "include: included.yaml\n"+testInvalidConfig)
+	path := filepath.Join(dir, configFile)
+	included := filepath.Join(dir, "included.yaml")
+
+	err := loadFiles([]string{path}, &defaultFramework{}, &loggingConfig{})
+	var configErrors ConfigErrors
+	assert.True(t, errors.As(err, &configErrors))
+	assert.Equal(t, []string{
+		included + ":3:12: unknown level 'chatty'",
+		path + ":6:9: unknown field encoder 'colour'",
+		path + ":8:19: unknown level format 'shouting'",
+		path + ":12:9: unknown key 'keys' in timestamp field",
+		path + ":16:3: writer 'file' is missing required path",
+		path + ":17:14: unknown encoding 'xml'",
+		path + ":19:10: unknown level 'verbose'",
+		path + ":22:7: root logger output references undefined writer 'stderr'",
+		path + ":27:19: sampling interval must be a positive integer",
+		path + ":30:16: unknown level 'loud'",
+		path + ":32:5: unknown key 'levels' in logger 'bar'",
+	}, errorStrings(configErrors))
+}
+
+func TestValidateFramework(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, configFile)
+	writeFile(t, path, `encoders:
+  console:
+    fields:
+      - name
+      - caller:
+          format: full
+  json:
+    fields:
+      - message:
+          key: msg
+writers:
+  stdout:
+    encoder: console
+`)
+	assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &loggingConfig{}))
+
+	err := loadFiles([]string{path}, &jsonFramework{}, &loggingConfig{})
+	var configErrors ConfigErrors
+	assert.True(t, errors.As(err, &configErrors))
+	assert.Equal(t, []string{
+		path + ":9:9: json framework: the JSON encoder does not support configuring message keys",
+		path + ":13:14: json framework does not support console encoding",
+	}, errorStrings(configErrors))
+}
+
+func TestValidateFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFile)
+	writeFile(t, path, testExplainConfig)
+	assert.NoError(t, ValidateFiles(nil, path))
+	assert.NoError(t, ValidateFiles(&defaultFramework{}, path))
+	assert.Error(t, ValidateFiles(&jsonFramework{}, path))
+}
+
+func TestValidateEmptyLevel(t *testing.T) {
+	path := filepath.Join(t.TempDir(), configFile)
+	writeFile(t, path, `writers:
+  stdout:
+    encoder: console
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+loggers:
+  foo:
+    level: ""
+`)
+	assert.NoError(t, ValidateFiles(nil, path))
+	var config loggingConfig
+	assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &config))
+	assert.Equal(t, EmptyLevel, config.Loggers["foo"].Level.Level())
+}
+
+func TestValidateExamples(t *testing.T) {
+	for _, path := range []string{"examples/logging.yaml", "examples/reference.yaml"} {
+		assert.NoError(t, loadFiles([]string{path}, &defaultFramework{}, &loggingConfig{}), path)
+	}
+}
+
+// jsonFramework is a framework that only supports JSON encoding without configurable keys
+type jsonFramework struct{}
+
+func (f *jsonFramework) Name() string {
+	return "json"
+}
+
+func (f *jsonFramework) JSONEncoder() Encoder {
+	return &jsonEncoder{}
+}
+
+type jsonEncoder struct {
+	Encoder
+}
+
+func errorStrings(errs ConfigErrors) []string {
+	var messages []string
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+	return messages
+}
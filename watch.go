@@ -0,0 +1,157 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultWatchInterval = 5 * time.Second
+
+// Watch watches the logging configuration files for changes, applying the new levels, samplers, outputs
+// and encoders to all existing loggers when any file changes. The configuration files are found again on
+// each check, so files created after watching started, e.g. in the working directory or at the path named
+// by LOGGING_CONFIG, are loaded as well. Files are read through symlinks on each check, so changes made by
+// replacing a symlink, e.g. when a Kubernetes ConfigMap is updated, are also detected. If the interval is
+// zero, the files are checked every five seconds. The returned function stops the watcher.
+func Watch(interval time.Duration) (func(), error) {
+	if interval == 0 {
+		interval = defaultWatchInterval
+	}
+	watcher, err := newConfigWatcher(configInputs, interval, open)
+	if err != nil {
+		return nil, err
+	}
+	go watcher.run()
+	return watcher.stop, nil
+}
+
+func newConfigWatcher(resolve func() ([]configInput, error), interval time.Duration, opener func(path string) (io.Writer, error)) (*configWatcher, error) {
+	inputs, err := resolve()
+	if err != nil {
+		return nil, err
+	}
+	source, err := readInputs(inputs)
+	if err != nil {
+		return nil, err
+	}
+	return &configWatcher{
+		resolve:  resolve,
+		interval: interval,
+		opener:   opener,
+		paths:    filePaths(inputs),
+		files:    source.files,
+		done:     make(chan struct{}),
+	}, nil
+}
+
+// configWatcher periodically checks the logging configuration files for changes
+type configWatcher struct {
+	resolve  func() ([]configInput, error)
+	interval time.Duration
+	opener   func(path string) (io.Writer, error)
+	paths    map[string]bool
+	files    map[string][]byte
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+func (w *configWatcher) run() {
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.check()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+func (w *configWatcher) stop() {
+	w.stopOnce.Do(func() {
+		close(w.done)
+	})
+}
+
+// check finds the configuration files and reloads the configuration if the set or contents of the files have changed
+func (w *configWatcher) check() {
+	inputs, err := w.resolve()
+	if err != nil {
+		root.Errorw("Failed to find logging configuration", Error(err))
+		return
+	}
+	paths := filePaths(inputs)
+	for path := range w.paths {
+		// A file may be briefly missing while it's being replaced
+		if !paths[path] {
+			return
+		}
+	}
+	source, err := readInputs(inputs)
+	if err != nil {
+		// An included file may also be briefly missing
+		if !errors.Is(err, os.ErrNotExist) {
+			root.Errorw("Failed to read logging configuration", Error(err))
+		}
+		return
+	}
+	if !changed(w.files, source.files) {
+		return
+	}
+	w.paths = paths
+	w.files = source.files
+	if err := reload(source, w.opener); err != nil {
+		root.Errorw("Failed to reload logging configuration", Error(err))
+	}
+}
+
+// filePaths returns the set of paths of the given inputs that are files in the OS filesystem
+func filePaths(inputs []configInput) map[string]bool {
+	paths := make(map[string]bool)
+	for _, input := range inputs {
+		if input.fsys == nil && input.data == nil {
+			paths[input.path] = true
+		}
+	}
+	return paths
+}
+
+// changed returns whether the set or contents of the configuration files have changed
+func changed(prev, next map[string][]byte) bool {
+	if len(prev) != len(next) {
+		return true
+	}
+	for path, data := range next {
+		if !bytes.Equal(prev[path], data) {
+			return true
+		}
+	}
+	return false
+}
+
+// reload applies the given configuration to all existing loggers using the current framework
+func reload(source *configSource, opener func(path string) (io.Writer, error)) error {
+	logger := root.(*dazlLogger)
+	framework := logger.getState().framework
+	if err := validate(source, framework); err != nil {
+		return err
+	}
+	var config loggingConfig
+	if err := source.decode(&config); err != nil {
+		return err
+	}
+	context, err := newLoggingContext(framework, config, opener)
+	if err != nil {
+		return err
+	}
+	return logger.reconfigure(context)
+}
@@ -0,0 +1,172 @@
+// SPDX-FileCopyrightText: 2023-present Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package dazl
+
+import (
+	"bytes"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testWatchConfigV1 = `
+encoders:
+  json:
+    fields:
+      - message
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+`
+
+const testWatchConfigV2 = `
+encoders:
+  json:
+    fields:
+      - message:
+          key: msg
+writers:
+  stdout:
+    encoder: json
+rootLogger:
+  level: info
+  outputs:
+    - stdout
+loggers:
+  watch:
+    level: debug
+`
+
+// syncBuffer is a bytes.Buffer that is safe for concurrent use
+type syncBuffer struct {
+	buf bytes.Buffer
+	mu  sync.Mutex
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func (b *syncBuffer) Reset() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.buf.Reset()
+}
+
+func TestWatch(t *testing.T) {
+	defer resetRootLogger()()
+
+	// Lay out the configuration the way Kubernetes mounts ConfigMaps, with the file linked through a data directory
+	dir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "..v1"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "..v1", configFile), []byte(testWatchConfigV1), 0644))
+	assert.NoError(t, os.Symlink("..v1", filepath.Join(dir, "..data")))
+	assert.NoError(t, os.Symlink(filepath.Join("..data", configFile), filepath.Join(dir, configFile)))
+	path := filepath.Join(dir, configFile)
+
+	buf := &syncBuffer{}
+	opener := func(path string) (io.Writer, error) {
+		return buf, nil
+	}
+
+	var config loggingConfig
+	assert.NoError(t, loadFile(path, &config))
+	assert.NoError(t, configure(&defaultFramework{}, config, opener))
+
+	log := GetLogger("watch")
+	fieldLog := log.WithFields(String("foo", "bar"))
+	childLog := log.GetLogger("child")
+
+	fieldLog.Debug("Hello world!")
+	childLog.Debug("Hello world!")
+	assert.Empty(t, buf.String())
+	fieldLog.Info("Hello world!")
+	assert.Equal(t, "{\"message\":\"Hello world!\",\"foo\":\"bar\"}\n", buf.String())
+	buf.Reset()
+
+	resolve := func() ([]configInput, error) {
+		return fileInputs([]string{path}), nil
+	}
+	watcher, err := newConfigWatcher(resolve, 10*time.Millisecond, opener)
+	assert.NoError(t, err)
+	go watcher.run()
+	defer watcher.stop()
+
+	// Replace the data directory link to update the configuration
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "..v2"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "..v2", configFile), []byte(testWatchConfigV2), 0644))
+	assert.NoError(t, os.Symlink("..v2", filepath.Join(dir, "..data_tmp")))
+	assert.NoError(t, os.Rename(filepath.Join(dir, "..data_tmp"), filepath.Join(dir, "..data")))
+
+	assert.Eventually(t, func() bool {
+		return log.Level() == DebugLevel
+	}, time.Second, 10*time.Millisecond)
+
+	fieldLog.Debug("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\",\"foo\":\"bar\"}\n", buf.String())
+	buf.Reset()
+
+	childLog.Debug("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	GetRootLogger().Debug("Hello world!")
+	assert.Empty(t, buf.String())
+
+	// Invalid configurations are not applied
+	invalid := filepath.Join(dir, "invalid.yaml")
+	assert.NoError(t, os.WriteFile(invalid, []byte("rootLogger:\n  outputs:\n    - stderr\n"), 0644))
+	source, err := readConfig([]string{invalid})
+	assert.NoError(t, err)
+	assert.Error(t, reload(source, opener))
+	fieldLog.Debug("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\",\"foo\":\"bar\"}\n", buf.String())
+}
+
+func TestWatchNewFile(t *testing.T) {
+	defer resetRootLogger()()
+
+	path := filepath.Join(t.TempDir(), configFile)
+	t.Setenv(configEnv, path)
+	t.Setenv(configDataEnv, "")
+
+	buf := &syncBuffer{}
+	opener := func(path string) (io.Writer, error) {
+		return buf, nil
+	}
+	assert.NoError(t, configure(&defaultFramework{}, loggingConfig{}, opener))
+	log := GetLogger("watch")
+	assert.NotEqual(t, DebugLevel, log.Level())
+
+	// The file named by LOGGING_CONFIG doesn't exist until after the watcher has started
+	watcher, err := newConfigWatcher(configInputs, 10*time.Millisecond, opener)
+	assert.NoError(t, err)
+	go watcher.run()
+	defer watcher.stop()
+
+	assert.NoError(t, os.WriteFile(path, []byte(testWatchConfigV2), 0644))
+	assert.Eventually(t, func() bool {
+		return log.Level() == DebugLevel
+	}, time.Second, 10*time.Millisecond)
+
+	log.Debug("Hello world!")
+	assert.Equal(t, "{\"msg\":\"Hello world!\"}\n", buf.String())
+}
@@ -14,12 +14,15 @@ import (
 type Writer interface {
 	WithName(name string) Writer
 	WithSkipCalls(calls int) Writer
+	Trace(msg string)
 	Debug(msg string)
 	Info(msg string)
 	Error(msg string)
 	Fatal(msg string)
 	Panic(msg string)
 	Warn(msg string)
+	// Log writes an entry at a custom level registered with RegisterLevel
+	Log(level Level, msg string)
 }
 
 type BasicSamplingWriter interface {
@@ -189,6 +192,25 @@ func (c *writersConfig) getFile(name string) (fileWriterConfig, bool) {
 	return config, ok
 }
 
+// getEncoding returns the encoding of the named writer, if the writer is configured
+func (c *writersConfig) getEncoding(name string) (Encoding, bool) {
+	switch name {
+	case "stdout":
+		if c.Stdout != nil {
+			return c.Stdout.Encoder, true
+		}
+	case "stderr":
+		if c.Stderr != nil {
+			return c.Stderr.Encoder, true
+		}
+	default:
+		if config, ok := c.getFile(name); ok {
+			return config.Encoder, true
+		}
+	}
+	return "", false
+}
+
 func (c *writersConfig) UnmarshalYAML(unmarshal func(any) error) error {
 	writers := make(map[string]any)
 	if err := unmarshal(writers); err != nil {
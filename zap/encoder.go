@@ -10,6 +10,7 @@ import (
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"io"
+	"strings"
 )
 
 type zapEncoder struct {
@@ -32,7 +33,7 @@ func (e *zapEncoder) WithNameEnabled() (dazl.Encoder, error) {
 func (e *zapEncoder) WithLevelEnabled() (dazl.Encoder, error) {
 	return e.with(func(config *zapcore.EncoderConfig) {
 		config.LevelKey = "level"
-		config.EncodeLevel = zapcore.LowercaseLevelEncoder
+		config.EncodeLevel = lowercaseLevelEncoder
 	}), nil
 }
 
@@ -40,17 +41,73 @@ func (e *zapEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder, err
 	switch format {
 	case dazl.LowerCaseLevelFormat:
 		return e.with(func(config *zapcore.EncoderConfig) {
-			config.EncodeLevel = zapcore.LowercaseLevelEncoder
+			config.EncodeLevel = lowercaseLevelEncoder
 		}), nil
 	case dazl.UpperCaseLevelFormat:
 		return e.with(func(config *zapcore.EncoderConfig) {
-			config.EncodeLevel = zapcore.CapitalLevelEncoder
+			config.EncodeLevel = capitalLevelEncoder
 		}), nil
 	default:
 		return nil, fmt.Errorf("unsupported level format '%s'", format)
 	}
 }
 
+// lowercaseLevelEncoder encodes levels in lowercase, encoding custom levels by name and other levels below
+// debug as trace
+func lowercaseLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if name, ok := customLevelName(level); ok {
+		enc.AppendString(name)
+		return
+	}
+	if level < zapcore.DebugLevel {
+		enc.AppendString(dazl.TraceLevel.String())
+		return
+	}
+	zapcore.LowercaseLevelEncoder(level, enc)
+}
+
+// capitalLevelEncoder encodes levels in uppercase, encoding custom levels by name and other levels below
+// debug as trace
+func capitalLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if name, ok := customLevelName(level); ok {
+		enc.AppendString(strings.ToUpper(name))
+		return
+	}
+	if level < zapcore.DebugLevel {
+		enc.AppendString(strings.ToUpper(dazl.TraceLevel.String()))
+		return
+	}
+	zapcore.CapitalLevelEncoder(level, enc)
+}
+
+// lowercaseColorLevelEncoder encodes levels in colored lowercase, coloring custom levels and trace like the
+// built-in level below them
+func lowercaseColorLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if custom, ok := customLevels.Load(level); ok {
+		enc.AppendString(dazl.ColorLevel(custom.(dazl.Level), custom.(dazl.Level).String()))
+		return
+	}
+	if level < zapcore.DebugLevel {
+		enc.AppendString(dazl.ColorLevel(dazl.TraceLevel, dazl.TraceLevel.String()))
+		return
+	}
+	zapcore.LowercaseColorLevelEncoder(level, enc)
+}
+
+// capitalColorLevelEncoder encodes levels in colored uppercase, coloring custom levels and trace like the
+// built-in level below them
+func capitalColorLevelEncoder(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	if custom, ok := customLevels.Load(level); ok {
+		enc.AppendString(dazl.ColorLevel(custom.(dazl.Level), strings.ToUpper(custom.(dazl.Level).String())))
+		return
+	}
+	if level < zapcore.DebugLevel {
+		enc.AppendString(dazl.ColorLevel(dazl.TraceLevel, strings.ToUpper(dazl.TraceLevel.String())))
+		return
+	}
+	zapcore.CapitalColorLevelEncoder(level, enc)
+}
+
 func (e *zapEncoder) WithTimestampEnabled() (dazl.Encoder, error) {
 	return e.with(func(config *zapcore.EncoderConfig) {
 		config.TimeKey = "time"
@@ -62,7 +119,7 @@ func (e *zapEncoder) WithTimestampFormat(format dazl.TimestampFormat) (dazl.Enco
 	switch format {
 	case dazl.ISO8601TimestampFormat:
 		return e.with(func(config *zapcore.EncoderConfig) {
-			config.EncodeTime = zapcore.ISO8601TimeEncoder
+			config.EncodeTime = zapcore.TimeEncoderOfLayout(dazl.ISO8601TimeLayout)
 		}), nil
 	case dazl.UnixTimestampFormat:
 		return e.with(func(config *zapcore.EncoderConfig) {
@@ -76,25 +133,28 @@ func (e *zapEncoder) WithTimestampFormat(format dazl.TimestampFormat) (dazl.Enco
 func (e *zapEncoder) WithCallerEnabled() (dazl.Encoder, error) {
 	return e.with(func(config *zapcore.EncoderConfig) {
 		config.CallerKey = "caller"
-		config.EncodeCaller = zapcore.ShortCallerEncoder
+		config.EncodeCaller = callerEncoder(dazl.ShortCallerFormat)
 	}), nil
 }
 
 func (e *zapEncoder) WithCallerFormat(format dazl.CallerFormat) (dazl.Encoder, error) {
 	switch format {
-	case dazl.ShortCallerFormat:
-		return e.with(func(config *zapcore.EncoderConfig) {
-			config.EncodeCaller = zapcore.ShortCallerEncoder
-		}), nil
-	case dazl.FullCallerFormat:
+	case dazl.ShortCallerFormat, dazl.FullCallerFormat:
 		return e.with(func(config *zapcore.EncoderConfig) {
-			config.EncodeCaller = zapcore.FullCallerEncoder
+			config.EncodeCaller = callerEncoder(format)
 		}), nil
 	default:
 		return nil, fmt.Errorf("unsupported caller format '%s'", format)
 	}
 }
 
+// callerEncoder returns a zapcore.CallerEncoder that encodes callers in the given format
+func callerEncoder(format dazl.CallerFormat) zapcore.CallerEncoder {
+	return func(caller zapcore.EntryCaller, enc zapcore.PrimitiveArrayEncoder) {
+		enc.AppendString(dazl.FormatCaller(caller.File, caller.Line, format))
+	}
+}
+
 func (e *zapEncoder) WithStacktraceEnabled() (dazl.Encoder, error) {
 	return e.with(func(config *zapcore.EncoderConfig) {
 		config.StacktraceKey = "trace"
@@ -125,6 +185,21 @@ func (e *consoleEncoder) NewWriter(writer io.Writer) (dazl.Writer, error) {
 	return newWriter(writer, zapcore.NewConsoleEncoder(e.config), config)
 }
 
+func (e *consoleEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder, error) {
+	switch format {
+	case dazl.LowerCaseColorLevelFormat:
+		return e.with(func(config *zapcore.EncoderConfig) {
+			config.EncodeLevel = lowercaseColorLevelEncoder
+		}), nil
+	case dazl.UpperCaseColorLevelFormat:
+		return e.with(func(config *zapcore.EncoderConfig) {
+			config.EncodeLevel = capitalColorLevelEncoder
+		}), nil
+	default:
+		return e.zapEncoder.WithLevelFormat(format)
+	}
+}
+
 var _ dazl.NameEncoder = (*consoleEncoder)(nil)
 var _ dazl.LevelEncoder = (*consoleEncoder)(nil)
 var _ dazl.LevelFormattingEncoder = (*consoleEncoder)(nil)
@@ -202,6 +202,17 @@ func TestConsoleEncoder(t *testing.T) {
 	writer.Warn("Hello world!")
 	assert.Equal(t, "WARN\tzap/encoder_test.go:202\tHello world!\n", buf.String())
 	buf.Reset()
+
+	encoder, err = encoder.(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseColorLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assert.Contains(t, buf.String(), "\x1b[31mERROR\x1b[0m\t")
+	buf.Reset()
+
+	_, err = newJSONEncoder(zapcore.EncoderConfig{}).(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseColorLevelFormat)
+	assert.Error(t, err)
 }
 
 func assertHasJSONKey(t *testing.T, key string, data []byte) bool {
@@ -5,18 +5,97 @@
 package zap
 
 import (
+	"fmt"
 	"github.com/atomix/dazl"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 	"io"
+	"math"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// DefaultTraceLevel is the zap level at which trace entries are written by default. zap has no trace level,
+// so trace entries are written one level below debug and encoded as "trace".
+const DefaultTraceLevel = zapcore.DebugLevel - 1
+
+var traceLevel atomic.Int32
+
+func init() {
+	traceLevel.Store(int32(DefaultTraceLevel))
+}
+
+// SetTraceLevel sets the zap level at which trace entries are written, e.g. zapcore.DebugLevel to write
+// trace entries as debug entries
+func SetTraceLevel(level zapcore.Level) {
+	traceLevel.Store(int32(level))
+}
+
+// zap has no custom levels, so entries at custom dazl levels are written at unused zap levels allocated upward
+// from the lowest zap level to the default trace level and encoded with the names of the custom levels
+var (
+	customLevels    sync.Map
+	customZapLevels sync.Map
+	customLevelsMu  sync.Mutex
+	nextCustomLevel = zapcore.Level(math.MinInt8)
+)
+
+// customZapLevel returns the zap level at which entries at the given custom level are written. An error is
+// returned if all the zap levels available for custom levels are in use.
+func customZapLevel(level dazl.Level) (zapcore.Level, error) {
+	if zapLevel, ok := customZapLevels.Load(level); ok {
+		return zapLevel.(zapcore.Level), nil
+	}
+	customLevelsMu.Lock()
+	defer customLevelsMu.Unlock()
+	if zapLevel, ok := customZapLevels.Load(level); ok {
+		return zapLevel.(zapcore.Level), nil
+	}
+	if nextCustomLevel >= DefaultTraceLevel {
+		return zapcore.InvalidLevel, fmt.Errorf("cannot write level '%s': all %d zap levels for custom levels are in use",
+			level, int(DefaultTraceLevel)-math.MinInt8)
+	}
+	zapLevel := nextCustomLevel
+	nextCustomLevel++
+	customLevels.Store(zapLevel, level)
+	customZapLevels.Store(level, zapLevel)
+	return zapLevel, nil
+}
+
+// builtinZapLevel returns the zap level of the built-in dazl level below the given level. Levels above the
+// error level are written at the error level, since zap panics and exits at the panic and fatal levels.
+func builtinZapLevel(level dazl.Level) zapcore.Level {
+	switch {
+	case dazl.ErrorLevel.Enabled(level):
+		return zapcore.ErrorLevel
+	case dazl.WarnLevel.Enabled(level):
+		return zapcore.WarnLevel
+	case dazl.InfoLevel.Enabled(level):
+		return zapcore.InfoLevel
+	case dazl.DebugLevel.Enabled(level):
+		return zapcore.DebugLevel
+	default:
+		return zapcore.Level(traceLevel.Load())
+	}
+}
+
+// customLevelName returns the name of the custom level written at the given zap level, if any
+func customLevelName(level zapcore.Level) (string, bool) {
+	if custom, ok := customLevels.Load(level); ok {
+		return custom.(dazl.Level).String(), true
+	}
+	return "", false
+}
+
 func newWriter(writer io.Writer, encoder zapcore.Encoder, config zap.Config) (dazl.Writer, error) {
 	logger, err := config.Build(
 		zap.AddCallerSkip(1),
 		zap.WrapCore(func(zapcore.Core) zapcore.Core {
-			return zapcore.NewCore(encoder, &writeSyncer{writer}, zap.DebugLevel)
+			// Levels are filtered by dazl, so entries are written at all levels including the trace level
+			return zapcore.NewCore(encoder, &writeSyncer{writer}, zap.LevelEnablerFunc(func(zapcore.Level) bool {
+				return true
+			}))
 		}))
 	if err != nil {
 		return nil, err
@@ -159,6 +238,10 @@ func (w *Writer) WithSkipCalls(calls int) dazl.Writer {
 	}
 }
 
+func (w *Writer) Trace(msg string) {
+	w.logger.Log(zapcore.Level(traceLevel.Load()), msg)
+}
+
 func (w *Writer) Debug(msg string) {
 	w.logger.Debug(msg)
 }
@@ -183,6 +266,15 @@ func (w *Writer) Warn(msg string) {
 	w.logger.Warn(msg)
 }
 
+func (w *Writer) Log(level dazl.Level, msg string) {
+	zapLevel, err := customZapLevel(level)
+	if err != nil {
+		// Rather than dropping the entry, write it at the nearest built-in level
+		zapLevel = builtinZapLevel(level)
+	}
+	w.logger.Log(zapLevel, msg)
+}
+
 func (w *Writer) Sync() error {
 	return w.logger.Sync()
 }
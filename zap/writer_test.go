@@ -18,13 +18,23 @@ func TestWriter(t *testing.T) {
 	config.Encoding = "json"
 	config.EncoderConfig.MessageKey = "message"
 	config.EncoderConfig.LevelKey = "level"
-	config.EncoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
+	config.EncoderConfig.EncodeLevel = lowercaseLevelEncoder
 	config.Level = zap.NewAtomicLevelAt(zap.DebugLevel)
 
 	buf := &bytes.Buffer{}
 	writer, err := newWriter(buf, zapcore.NewJSONEncoder(config.EncoderConfig), config)
 	assert.NoError(t, err)
 
+	writer.Trace("Hello world!")
+	assert.Equal(t, "{\"level\":\"trace\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	SetTraceLevel(zapcore.DebugLevel)
+	writer.Trace("Hello world!")
+	assert.Equal(t, "{\"level\":\"debug\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+	SetTraceLevel(DefaultTraceLevel)
+
 	writer.Debug("Hello world!")
 	assert.Equal(t, "{\"level\":\"debug\",\"message\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()
@@ -41,6 +51,27 @@ func TestWriter(t *testing.T) {
 	assert.Equal(t, "{\"level\":\"error\",\"message\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()
 
+	assert.NoError(t, dazl.RegisterLevel("audit", 45))
+	writer.Log(45, "Hello world!")
+	assert.Equal(t, "{\"level\":\"audit\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
+	// When the zap levels for custom levels are exhausted, entries are written at the nearest built-in level
+	customLevelsMu.Lock()
+	next := nextCustomLevel
+	nextCustomLevel = DefaultTraceLevel
+	customLevelsMu.Unlock()
+	_, err = customZapLevel(46)
+	assert.EqualError(t, err, "cannot write level '46': all 126 zap levels for custom levels are in use")
+	writer.Log(46, "Hello world!")
+	assert.Equal(t, "{\"level\":\"warn\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+	_, err = customZapLevel(45)
+	assert.NoError(t, err)
+	customLevelsMu.Lock()
+	nextCustomLevel = next
+	customLevelsMu.Unlock()
+
 	writer.(dazl.StringFieldWriter).WithStringField("foo", "bar").Info("Hello world!")
 	assert.Equal(t, "{\"level\":\"info\",\"message\":\"Hello world!\",\"foo\":\"bar\"}\n", buf.String())
 	buf.Reset()
@@ -9,10 +9,7 @@ import (
 	"github.com/atomix/dazl"
 	"github.com/rs/zerolog"
 	"io"
-	"path/filepath"
-	"strconv"
 	"strings"
-	"time"
 )
 
 type consoleEncoder struct {
@@ -74,11 +71,33 @@ func (e *consoleEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder,
 			return strings.ToUpper(fmt.Sprint(level))
 		}
 		return e, nil
+	case dazl.LowerCaseColorLevelFormat:
+		e.writer.FormatLevel = func(level interface{}) string {
+			name := strings.ToLower(fmt.Sprint(level))
+			return colorLevel(name, name)
+		}
+		return e, nil
+	case dazl.UpperCaseColorLevelFormat:
+		e.writer.FormatLevel = func(level interface{}) string {
+			name := strings.ToLower(fmt.Sprint(level))
+			return colorLevel(name, strings.ToUpper(name))
+		}
+		return e, nil
 	default:
 		return nil, fmt.Errorf("unsupported level format '%s'", format)
 	}
 }
 
+// colorLevel wraps the formatted level name in the ANSI color of the named level. Custom levels are colored
+// like the built-in level below them.
+func colorLevel(name string, formatted string) string {
+	level, ok := parseLevel(name)
+	if !ok {
+		return formatted
+	}
+	return dazl.ColorLevel(level, formatted)
+}
+
 func (e *consoleEncoder) WithTimestampEnabled() (dazl.Encoder, error) {
 	e.timestamp = true
 	return e, nil
@@ -89,7 +108,7 @@ func (e *consoleEncoder) WithTimestampFormat(format dazl.TimestampFormat) (dazl.
 	case dazl.UnixTimestampFormat:
 		e.writer.TimeFormat = zerolog.TimeFormatUnix
 	case dazl.ISO8601TimestampFormat:
-		e.writer.TimeFormat = time.RFC3339
+		e.writer.TimeFormat = dazl.ISO8601TimeLayout
 	default:
 		return nil, fmt.Errorf("unsupoorted timestamp format %s", format)
 	}
@@ -174,6 +193,8 @@ func (e *jsonEncoder) WithLevelFormat(format dazl.LevelFormat) (dazl.Encoder, er
 		zerolog.LevelErrorValue = "ERROR"
 		zerolog.LevelFatalValue = "FATAL"
 		zerolog.LevelPanicValue = "PANIC"
+	default:
+		return nil, fmt.Errorf("unsupported level format '%s'", format)
 	}
 	return e, nil
 }
@@ -193,7 +214,7 @@ func (e *jsonEncoder) WithTimestampFormat(format dazl.TimestampFormat) (dazl.Enc
 	case dazl.UnixTimestampFormat:
 		zerolog.TimeFieldFormat = zerolog.TimeFormatUnix
 	case dazl.ISO8601TimestampFormat:
-		zerolog.TimeFieldFormat = time.RFC3339
+		zerolog.TimeFieldFormat = dazl.ISO8601TimeLayout
 	default:
 		return nil, fmt.Errorf("unsupoorted timestamp format %s", format)
 	}
@@ -212,13 +233,9 @@ func (e *jsonEncoder) WithCallerKey(key string) (dazl.Encoder, error) {
 
 func (e *jsonEncoder) WithCallerFormat(format dazl.CallerFormat) (dazl.Encoder, error) {
 	switch format {
-	case dazl.ShortCallerFormat:
-		zerolog.CallerMarshalFunc = func(pc uintptr, file string, line int) string {
-			return filepath.Base(file) + ":" + strconv.Itoa(line)
-		}
-	case dazl.FullCallerFormat:
+	case dazl.ShortCallerFormat, dazl.FullCallerFormat:
 		zerolog.CallerMarshalFunc = func(pc uintptr, file string, line int) string {
-			return file + ":" + strconv.Itoa(line)
+			return dazl.FormatCaller(file, line, format)
 		}
 	default:
 		return nil, fmt.Errorf("unsupoorted caller format %s", format)
@@ -89,7 +89,7 @@ func TestJSONEncoder(t *testing.T) {
 	writer, err = encoder.NewWriter(buf)
 	assert.NoError(t, err)
 	writer.Info("Hello world!")
-	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"encoder_test.go:91\",\"msg\":\"Hello world!\"}\n", buf.String())
+	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"zerolog/encoder_test.go:91\",\"msg\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()
 
 	encoder, err = encoder.(dazl.CallerKeyEncoder).WithCallerKey("call")
@@ -97,7 +97,7 @@ func TestJSONEncoder(t *testing.T) {
 	writer, err = encoder.NewWriter(buf)
 	assert.NoError(t, err)
 	writer.Info("Hello world!")
-	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"encoder_test.go:99\",\"msg\":\"Hello world!\"}\n", buf.String())
+	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"zerolog/encoder_test.go:99\",\"msg\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()
 
 	encoder, err = encoder.(dazl.CallerFormattingEncoder).WithCallerFormat(dazl.ShortCallerFormat)
@@ -105,7 +105,7 @@ func TestJSONEncoder(t *testing.T) {
 	writer, err = encoder.NewWriter(buf)
 	assert.NoError(t, err)
 	writer.Info("Hello world!")
-	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"encoder_test.go:107\",\"msg\":\"Hello world!\"}\n", buf.String())
+	assert.Equal(t, "{\"lvl\":\"INFO\",\"call\":\"zerolog/encoder_test.go:107\",\"msg\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()
 
 	encoder, err = encoder.(dazl.CallerFormattingEncoder).WithCallerFormat(dazl.FullCallerFormat)
@@ -195,6 +195,17 @@ func TestConsoleEncoder(t *testing.T) {
 	writer.Info("Hello world!")
 	assert.Equal(t, "INFO \x1b[1mencoder_test.go:195\x1b[0m\x1b[36m >\x1b[0m Hello world!\n", buf.String())
 	buf.Reset()
+
+	encoder, err = framework.ConsoleEncoder().(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseColorLevelFormat)
+	assert.NoError(t, err)
+	writer, err = encoder.NewWriter(buf)
+	assert.NoError(t, err)
+	writer.Error("Hello world!")
+	assert.Equal(t, "\x1b[31mERROR\x1b[0m Hello world!\n", buf.String())
+	buf.Reset()
+
+	_, err = framework.JSONEncoder().(dazl.LevelFormattingEncoder).WithLevelFormat(dazl.UpperCaseColorLevelFormat)
+	assert.Error(t, err)
 }
 
 func assertHasJSONKey(t *testing.T, key string, data []byte) bool {
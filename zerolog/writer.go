@@ -7,6 +7,8 @@ package zerolog
 import (
 	"github.com/atomix/dazl"
 	"github.com/rs/zerolog"
+	"strings"
+	"sync"
 	"time"
 )
 
@@ -18,6 +20,10 @@ type Writer struct {
 	skipFrames int
 }
 
+func (w *Writer) Trace(msg string) {
+	w.logger.Trace().Msg(msg)
+}
+
 func (w *Writer) Debug(msg string) {
 	w.logger.Debug().Msg(msg)
 }
@@ -42,6 +48,37 @@ func (w *Writer) Warn(msg string) {
 	w.logger.Warn().Msg(msg)
 }
 
+func (w *Writer) Log(level dazl.Level, msg string) {
+	// zerolog has no custom levels, so the level field is written with the custom level name
+	w.logger.Log().Str(zerolog.LevelFieldName, formatLevel(level)).Msg(msg)
+}
+
+// customLevels are the custom dazl levels written by Log, keyed by name
+var customLevels sync.Map
+
+// parseLevel returns the built-in or custom dazl level with the given lower case name
+func parseLevel(name string) (dazl.Level, bool) {
+	for _, level := range []dazl.Level{dazl.TraceLevel, dazl.DebugLevel, dazl.InfoLevel, dazl.WarnLevel,
+		dazl.ErrorLevel, dazl.PanicLevel, dazl.FatalLevel} {
+		if name == level.String() {
+			return level, true
+		}
+	}
+	if level, ok := customLevels.Load(name); ok {
+		return level.(dazl.Level), true
+	}
+	return dazl.EmptyLevel, false
+}
+
+// formatLevel formats the name of a custom level in the case in which the built-in levels are written
+func formatLevel(level dazl.Level) string {
+	customLevels.LoadOrStore(level.String(), level)
+	if info := zerolog.LevelFieldMarshalFunc(zerolog.InfoLevel); info == strings.ToUpper(info) {
+		return strings.ToUpper(level.String())
+	}
+	return level.String()
+}
+
 func (w *Writer) withLogger(logger zerolog.Logger) dazl.Writer {
 	return &Writer{
 		logger:     logger,
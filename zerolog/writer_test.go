@@ -25,6 +25,10 @@ func TestWriter(t *testing.T) {
 		logger: zerolog.New(buf),
 	}
 
+	writer.Trace("Hello world!")
+	assert.Equal(t, "{\"level\":\"trace\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
 	writer.Debug("Hello world!")
 	assert.Equal(t, "{\"level\":\"debug\",\"message\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()
@@ -41,6 +45,11 @@ func TestWriter(t *testing.T) {
 	assert.Equal(t, "{\"level\":\"error\",\"message\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()
 
+	assert.NoError(t, dazl.RegisterLevel("audit", 45))
+	writer.Log(45, "Hello world!")
+	assert.Equal(t, "{\"level\":\"audit\",\"message\":\"Hello world!\"}\n", buf.String())
+	buf.Reset()
+
 	writer.(dazl.StringFieldWriter).WithStringField("foo", "bar").Info("Hello world!")
 	assert.Equal(t, "{\"level\":\"info\",\"foo\":\"bar\",\"message\":\"Hello world!\"}\n", buf.String())
 	buf.Reset()